@@ -9,22 +9,42 @@ import (
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"golang.org/x/oauth2"
 )
 
+// Option configures NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to make requests. The default is
+// http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
 // NewClient constructs a new SAMS Accounts client, pointed to the supplied SAMS host.
 // e.g. "https://accounts.sourcegraph.com".
 //
 // Users should prefer to use the top-level 'sams.NewAccountsV1' constructor instead.
-func NewClient(samsHost string, tokenSource oauth2.TokenSource) *Client {
+func NewClient(samsHost string, tokenSource oauth2.TokenSource, opts ...Option) *Client {
 	// Canonicalize the host so we only need to check if it ends in a slash or not once.
 	samsHost = strings.ToLower(samsHost)
 	samsHost = strings.TrimSuffix(samsHost, "/")
 
-	return &Client{
+	c := &Client{
 		host:        samsHost,
 		tokenSource: tokenSource,
+		httpClient:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Client is a wrapper around SAMS primitive REST-based Accounts API. Most
@@ -37,29 +57,39 @@ func NewClient(samsHost string, tokenSource oauth2.TokenSource) *Client {
 type Client struct {
 	host        string
 	tokenSource oauth2.TokenSource
+	httpClient  *http.Client
 }
 
 // GetUser returns the basic user profile of the calling user. (Who owns the
 // underlying token or TokenSource the client is using for authentication.)
 //
-// If the supplied token is invalid, malformed, or expired, the error will contain
-// "unexpected status 401".
+// If the supplied token is invalid, malformed, or expired, the error matches
+// ErrUnauthorized with errors.Is. Use errors.As with *StatusError to inspect
+// any unexpected response.
 func (c *Client) GetUser(ctx context.Context) (*User, error) {
-	url := fmt.Sprintf("%s/api/v1/user", c.host)
-
 	token, err := c.tokenSource.Token()
 	if err != nil {
 		return nil, errors.Wrap(err, "getting token")
 	}
+	return c.GetUserWithAccessToken(ctx, token.AccessToken)
+}
+
+// GetUserWithAccessToken is like GetUser, but authenticates with the given
+// access token instead of the TokenSource of the client. It allows a single
+// client to look up the owners of many access tokens, e.g. in a server that
+// authenticates incoming requests.
+func (c *Client) GetUserWithAccessToken(ctx context.Context, accessToken string) (*User, error) {
+	url := fmt.Sprintf("%s/api/v1/user", c.host)
 
-	req, err := http.NewRequest(http.MethodGet, url, nil /* body */)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil /* body */)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating SAMS user details request")
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	req.Header.Add("User-Agent", "sourcegraph-accounts-sdk-go/1")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, errors.Wrap(err, "fetching user details")
 	}
@@ -74,10 +104,10 @@ func (c *Client) GetUser(ctx context.Context) (*User, error) {
 		return nil, errors.Wrap(err, "closing response body")
 	}
 	if resp.StatusCode != http.StatusOK {
-		unexpectedRespErr := errors.Errorf(
-			"unexpected status %d (response body: %s)",
-			resp.StatusCode, string(bodyBytes))
-		return nil, unexpectedRespErr
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       string(bodyBytes),
+		}
 	}
 
 	var user User
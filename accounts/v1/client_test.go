@@ -0,0 +1,152 @@
+package accountsv1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+var testTokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "sams_at_test"})
+
+func TestGetUser(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/user", r.URL.Path)
+		assert.Equal(t, "Bearer sams_at_test", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(User{Sub: "user-1", Email: "alice@example.com"})
+	})
+
+	user, err := NewClient(srv.URL+"/", testTokenSource).GetUser(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.Sub)
+	assert.Equal(t, "alice@example.com", user.Email)
+}
+
+func TestGetUserWithAccessToken(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sams_at_other", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(User{Sub: "user-2"})
+	})
+
+	user, err := NewClient(srv.URL, testTokenSource).GetUserWithAccessToken(context.Background(), "sams_at_other")
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", user.Sub)
+}
+
+func TestGetUserErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		statusCode int
+		wantIs     error
+		wantError  autogold.Value
+	}{{
+		name:       "unauthorized",
+		statusCode: http.StatusUnauthorized,
+		wantIs:     ErrUnauthorized,
+		wantError:  autogold.Expect("unexpected status 401 (response body: oops\n)"),
+	}, {
+		name:       "forbidden",
+		statusCode: http.StatusForbidden,
+		wantIs:     ErrForbidden,
+		wantError:  autogold.Expect("unexpected status 403 (response body: oops\n)"),
+	}, {
+		name:       "not found",
+		statusCode: http.StatusNotFound,
+		wantIs:     ErrNotFound,
+		wantError:  autogold.Expect("unexpected status 404 (response body: oops\n)"),
+	}, {
+		name:       "server error",
+		statusCode: http.StatusBadGateway,
+		wantIs:     ErrServerError,
+		wantError:  autogold.Expect("unexpected status 502 (response body: oops\n)"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "oops", tc.statusCode)
+			})
+
+			_, err := NewClient(srv.URL, testTokenSource).GetUser(context.Background())
+			require.Error(t, err)
+			tc.wantError.Equal(t, err.Error())
+			assert.True(t, errors.Is(err, tc.wantIs))
+			for _, other := range []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrServerError} {
+				if other != tc.wantIs {
+					assert.False(t, errors.Is(err, other))
+				}
+			}
+
+			var statusErr *StatusError
+			require.True(t, errors.As(err, &statusErr))
+			assert.Equal(t, tc.statusCode, statusErr.StatusCode)
+		})
+	}
+}
+
+func TestGetUserContext(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-unblock:
+		}
+	})
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := NewClient(srv.URL, testTokenSource).GetUser(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, r)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestGetUserHTTPClientAndPropagation(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(User{Sub: "user-1"})
+	})
+
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("b7ad6b7169203331")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	transport := &recordingTransport{}
+	_, err = NewClient(srv.URL, testTokenSource, WithHTTPClient(&http.Client{Transport: transport})).GetUser(ctx)
+	require.NoError(t, err)
+	require.Len(t, transport.requests, 1)
+	autogold.Expect("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01").Equal(t, transport.requests[0].Header.Get("traceparent"))
+}
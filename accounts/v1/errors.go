@@ -0,0 +1,57 @@
+package accountsv1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	// ErrUnauthorized is returned when the token is invalid, malformed, or
+	// expired.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the token lacks the required scopes.
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrServerError is returned when SAMS fails to process the request, which
+	// may be retried.
+	ErrServerError = errors.New("server error")
+)
+
+// maxStatusErrorBodyLength is the maximum length of the response body included
+// in the error message of StatusError.
+const maxStatusErrorBodyLength = 1024
+
+// StatusError is returned for any unexpected response status. It matches
+// ErrUnauthorized, ErrForbidden, ErrNotFound, or ErrServerError with errors.Is
+// depending on the status code.
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Body is the body of the response.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	body := e.Body
+	if len(body) > maxStatusErrorBodyLength {
+		body = body[:maxStatusErrorBodyLength] + "..."
+	}
+	return fmt.Sprintf("unexpected status %d (response body: %s)", e.StatusCode, body)
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
@@ -1,6 +1,8 @@
 package sams
 
 import (
+	"net/http"
+
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"golang.org/x/oauth2"
 
@@ -12,11 +14,15 @@ type AccountsV1Config struct {
 	// TokenSource is the OAuth2 token source to use for authentication. It MUST be
 	// based on a per-user token that is on behalf of a SAMS user.
 	//
-	// If you have the SAMS user's refresh token, using the oauth2.TokenSource
-	// abstraction will take care of creating short-lived access tokens and refresh
-	// as needed. But if you only have the access token, you will need to use a
-	// StaticTokenSource instead.
+	// If you have the SAMS user's refresh token, use tokenstore.NewTokenSource
+	// from the auth/tokenstore package, which takes care of creating short-lived
+	// access tokens as needed and persisting rotated refresh tokens. But if you
+	// only have the access token, you will need to use a StaticTokenSource
+	// instead.
 	TokenSource oauth2.TokenSource
+	// HTTPClient is the HTTP client used to make requests (optional, defaults to
+	// http.DefaultClient).
+	HTTPClient *http.Client
 }
 
 func (c AccountsV1Config) Validate() error {
@@ -34,5 +40,9 @@ func NewAccountsV1(config AccountsV1Config) (*accountsv1.Client, error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
-	return accountsv1.NewClient(config.getAPIURL(), config.TokenSource), nil
+	return accountsv1.NewClient(
+		config.getAPIURL(),
+		config.TokenSource,
+		accountsv1.WithHTTPClient(config.HTTPClient),
+	), nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"golang.org/x/oauth2"
 
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
 )
 
@@ -21,8 +22,9 @@ type Config struct {
 	Issuer string
 	// ClientID is the SAMS client ID, e.g. "sams_cid_xxx".
 	ClientID string
-	// ClientSecret is the SAMS client secret, e.g. "sams_cs_xxx".
-	ClientSecret string
+	// ClientSecret is the SAMS client secret, e.g. "sams_cs_xxx". It may be empty
+	// for public clients, which requires PKCE.
+	ClientSecret sams.Secret
 	// RequestScopes is the list of requested scopes for access tokens that are
 	// issued to this client.
 	RequestScopes []scopes.Scope
@@ -33,12 +35,23 @@ type Config struct {
 	// FailureHandler is the HTTP handler to call when an error occurs. Use
 	// ErrorFromContext to extract the error.
 	FailureHandler http.Handler
+	// DisablePKCE disables Proof Key for Code Exchange (RFC 7636), which is
+	// enabled by default with the S256 method when the SecretStore implements
+	// CodeVerifierStore. It SHOULD NOT be disabled unless the SAMS client does
+	// not support PKCE.
+	DisablePKCE bool
+	// ReturnTo configures the "return_to" query parameter of LoginHandler, which
+	// is only supported when the SecretStore implements ReturnToStore, and
+	// ignored otherwise.
+	ReturnTo ReturnToConfig
 
 	SecretStore
 }
 
 // SecretStore is the interface for managing the authentication state and nonce
-// in the per-user session.
+// in the per-user session. Implementations SHOULD also implement
+// CodeVerifierStore to enable PKCE, and MAY implement ReturnToStore to support
+// return-to URLs.
 type SecretStore interface {
 	// SetState sets the randomly-generated state to the per-user session.
 	SetState(r *http.Request, state string) error
@@ -54,6 +67,33 @@ type SecretStore interface {
 	DeleteNonce(r *http.Request)
 }
 
+// CodeVerifierStore is the optional interface of a SecretStore for managing the
+// PKCE code verifier in the per-user session. PKCE is only used when the
+// SecretStore implements it, so that existing SecretStore implementations keep
+// working unchanged.
+type CodeVerifierStore interface {
+	// SetCodeVerifier sets the randomly-generated PKCE code verifier to the
+	// per-user session.
+	SetCodeVerifier(r *http.Request, verifier string) error
+	// GetCodeVerifier returns the PKCE code verifier from the per-user session.
+	GetCodeVerifier(r *http.Request) (string, error)
+	// DeleteCodeVerifier deletes the PKCE code verifier from the per-user session.
+	DeleteCodeVerifier(r *http.Request)
+}
+
+// ReturnToStore is the optional interface of a SecretStore for managing the
+// return-to URL in the per-user session. The "return_to" query parameter of
+// LoginHandler is only supported when the SecretStore implements it, so that
+// existing SecretStore implementations keep working unchanged.
+type ReturnToStore interface {
+	// SetReturnTo sets the validated return-to URL to the per-user session.
+	SetReturnTo(r *http.Request, returnTo string) error
+	// GetReturnTo returns the return-to URL from the per-user session.
+	GetReturnTo(r *http.Request) (string, error)
+	// DeleteReturnTo deletes the return-to URL from the per-user session.
+	DeleteReturnTo(r *http.Request)
+}
+
 // Error is an error that occurred during the authentication process.
 type Error struct {
 	// StatusCode is the HTTP status code to respond with.
@@ -65,6 +105,12 @@ type Error struct {
 // Handler is the SAMS authentication handler.
 type Handler struct {
 	config Config
+	// codeVerifiers is the SecretStore as a CodeVerifierStore, which is nil if
+	// PKCE is not used.
+	codeVerifiers CodeVerifierStore
+	// returnTos is the SecretStore as a ReturnToStore, which is nil if return-to
+	// URLs are not supported.
+	returnTos ReturnToStore
 }
 
 // NewHandler returns a new SAMS authentication handler with the given
@@ -75,17 +121,48 @@ func NewHandler(config Config) (*Handler, error) {
 	} else if config.SecretStore == nil {
 		return nil, errors.New("missing SecretStore")
 	}
-	return &Handler{config: config}, nil
+
+	h := &Handler{config: config}
+	if !config.DisablePKCE {
+		h.codeVerifiers, _ = config.SecretStore.(CodeVerifierStore)
+	}
+	if config.ClientSecret == "" && h.codeVerifiers == nil {
+		return nil, errors.New("missing ClientSecret, which is required when PKCE is disabled or the SecretStore does not implement CodeVerifierStore")
+	}
+	h.returnTos, _ = config.SecretStore.(ReturnToStore)
+	if h.returnTos == nil && (len(config.ReturnTo.AllowedOrigins) > 0 || len(config.ReturnTo.AllowedPaths) > 0) {
+		return nil, errors.New("ReturnTo is configured, but the SecretStore does not implement ReturnToStore")
+	}
+	return h, nil
 }
 
 // LoginHandler returns an HTTP handler that redirects the user to the SAMS
 // authentication page.
 //
 // It passes through the "prompt" and "prompt_auth" query parameters to SAMS.
+// The "return_to" query parameter is validated against Config.ReturnTo and
+// made available to the success handler of CallbackHandler, see
+// ReturnToFromContext. It is ignored if the SecretStore does not implement
+// ReturnToStore.
 func (h *Handler) LoginHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
+		var returnTo string
+		if h.returnTos != nil {
+			returnTo = r.URL.Query().Get("return_to")
+		}
+		if returnTo != "" {
+			if err := h.config.ReturnTo.Validate(returnTo); err != nil {
+				ctx = WithError(ctx, &Error{
+					StatusCode: http.StatusBadRequest,
+					Cause:      errors.Wrapf(err, "invalid return-to URL %q", returnTo),
+				})
+				h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+
 		// Create a new OIDC provider from the issuer URL using OIDC discovery feature.
 		p, err := oidc.NewProvider(ctx, h.config.Issuer)
 		if err != nil {
@@ -140,6 +217,22 @@ func (h *Handler) LoginHandler() http.Handler {
 			return
 		}
 
+		// Always overwrite the return-to URL, so that a stale one from an abandoned
+		// login is never used.
+		if returnTo != "" {
+			err = h.returnTos.SetReturnTo(r, returnTo)
+			if err != nil {
+				ctx = WithError(ctx, &Error{
+					StatusCode: http.StatusInternalServerError,
+					Cause:      errors.Wrap(err, "set return-to URL"),
+				})
+				h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		} else if h.returnTos != nil {
+			h.returnTos.DeleteReturnTo(r)
+		}
+
 		params := url.Values{}
 		params.Add("client_id", h.config.ClientID)
 		params.Add("redirect_uri", h.config.RedirectURI)
@@ -148,6 +241,24 @@ func (h *Handler) LoginHandler() http.Handler {
 		params.Add("response_type", "code")
 		params.Add("scope", strings.Join(scopes.ToStrings(h.config.RequestScopes), " "))
 
+		if h.codeVerifiers != nil {
+			// Generate and store a random code verifier to the session, and only send
+			// its challenge. The code verifier is sent when exchanging the code, so that
+			// an intercepted code cannot be exchanged by anyone else.
+			verifier := oauth2.GenerateVerifier()
+			err = h.codeVerifiers.SetCodeVerifier(r, verifier)
+			if err != nil {
+				ctx = WithError(ctx, &Error{
+					StatusCode: http.StatusInternalServerError,
+					Cause:      errors.Wrap(err, "set code verifier"),
+				})
+				h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+			params.Add("code_challenge", oauth2.S256ChallengeFromVerifier(verifier))
+			params.Add("code_challenge_method", "S256")
+		}
+
 		// Passthrough the IdP-aware query parameters.
 		params.Add("prompt", r.URL.Query().Get("prompt"))
 		params.Add("prompt_auth", r.URL.Query().Get("prompt_auth"))
@@ -158,7 +269,8 @@ func (h *Handler) LoginHandler() http.Handler {
 
 // CallbackHandler returns an HTTP handler that handles the SAMS callback and
 // calls the success handler upon successful authentication. Use
-// UserInfoFromContext to extract the user information.
+// UserInfoFromContext to extract the user information, and ReturnToFromContext
+// to extract the validated return-to URL requested upon login.
 func (h *Handler) CallbackHandler(success http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -182,6 +294,26 @@ func (h *Handler) CallbackHandler(success http.Handler) http.Handler {
 			return
 		}
 
+		var returnTo string
+		if h.returnTos != nil {
+			returnTo, err = h.returnTos.GetReturnTo(r)
+			h.returnTos.DeleteReturnTo(r) // Delete the return-to URL after getting it to make sure it's one-time use.
+			if err != nil {
+				ctx = WithError(ctx, &Error{
+					StatusCode: http.StatusInternalServerError,
+					Cause:      errors.Wrap(err, "get return-to URL"),
+				})
+				h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+		// Validate again in case the configuration has changed since the login, and
+		// drop the return-to URL instead of failing the login if it is no longer
+		// allowed.
+		if returnTo != "" && h.config.ReturnTo.Validate(returnTo) != nil {
+			returnTo = ""
+		}
+
 		userInfo, err := h.getUserInfo(r)
 		if err != nil {
 			ctx = WithError(ctx, &Error{
@@ -193,6 +325,7 @@ func (h *Handler) CallbackHandler(success http.Handler) http.Handler {
 		}
 
 		ctx = WithUserInfo(ctx, userInfo)
+		ctx = WithReturnTo(ctx, returnTo)
 		success.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -252,7 +385,7 @@ func (h *Handler) getUserInfo(r *http.Request) (*UserInfo, error) {
 
 	oauth2Config := oauth2.Config{
 		ClientID:     h.config.ClientID,
-		ClientSecret: h.config.ClientSecret,
+		ClientSecret: h.config.ClientSecret.Reveal(),
 		RedirectURL:  h.config.RedirectURI,
 
 		// Discovery returns the OAuth2 endpoints.
@@ -260,8 +393,21 @@ func (h *Handler) getUserInfo(r *http.Request) (*UserInfo, error) {
 		Scopes:   scopes.ToStrings(h.config.RequestScopes),
 	}
 
+	var exchangeOpts []oauth2.AuthCodeOption
+	if h.codeVerifiers != nil {
+		verifier, err := h.codeVerifiers.GetCodeVerifier(r)
+		h.codeVerifiers.DeleteCodeVerifier(r) // Delete the code verifier after getting it to make sure it's one-time use.
+		if err != nil {
+			return nil, errors.Wrap(err, "get code verifier")
+		}
+		if verifier == "" {
+			return nil, errors.New("missing code verifier")
+		}
+		exchangeOpts = append(exchangeOpts, oauth2.VerifierOption(verifier))
+	}
+
 	code := r.URL.Query().Get("code")
-	token, err := oauth2Config.Exchange(ctx, code)
+	token, err := oauth2Config.Exchange(ctx, code, exchangeOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "exchange token")
 	}
@@ -3,6 +3,8 @@ package auth
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -18,7 +20,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
 )
 
@@ -45,7 +49,7 @@ func newMockServer(t *testing.T, redirectURI, clientID, subject, code, accessTok
 
 	rs256, err := rsa.GenerateKey(rand.Reader, 2048)
 	require.NoError(t, err)
-	var rawIDToken string
+	var rawIDToken, codeChallenge string
 	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
 		// Assert all desired parameters are present.
 		assert.Equal(t, clientID, r.URL.Query().Get("client_id"))
@@ -59,6 +63,10 @@ func newMockServer(t *testing.T, redirectURI, clientID, subject, code, accessTok
 		assert.NotEmpty(t, state)
 		nonce := r.URL.Query().Get("nonce")
 		assert.NotEmpty(t, nonce)
+		codeChallenge = r.URL.Query().Get("code_challenge")
+		if codeChallenge != "" {
+			assert.Equal(t, "S256", r.URL.Query().Get("code_challenge_method"))
+		}
 
 		token := jwt.NewWithClaims(
 			jwt.SigningMethodRS256,
@@ -88,6 +96,18 @@ func newMockServer(t *testing.T, redirectURI, clientID, subject, code, accessTok
 		assert.Equal(t, code, vals.Get("code"))
 		assert.Equal(t, "authorization_code", vals.Get("grant_type"))
 		assert.Equal(t, redirectURI, vals.Get("redirect_uri"))
+		if codeChallenge != "" {
+			verifier := vals.Get("code_verifier")
+			sum := sha256.Sum256([]byte(verifier))
+			if base64.RawURLEncoding.EncodeToString(sum[:]) != codeChallenge {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+		} else {
+			assert.Empty(t, vals.Get("code_verifier"))
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
@@ -128,6 +148,8 @@ func newMockServer(t *testing.T, redirectURI, clientID, subject, code, accessTok
 type mockSecretStore struct {
 	state         string
 	nonce         string
+	codeVerifier  string
+	returnTo      string
 	setStateError error
 }
 
@@ -160,55 +182,101 @@ func (s *mockSecretStore) DeleteNonce(r *http.Request) {
 	s.nonce = ""
 }
 
-func TestHandler(t *testing.T) {
-	const (
-		testClientID    = "test-client-id"
-		testCode        = "test-code"
-		testAccessToken = "test-access-token"
-		testSubject     = "018d21f2-0b8d-756a-84f0-13b942a2bae5"
-		testName        = "John Doe"
-		testEmail       = "john.doe@example.com"
-	)
-	someday, err := time.Parse(time.DateOnly, "2021-01-01")
-	require.NoError(t, err)
+func (s *mockSecretStore) SetCodeVerifier(_ *http.Request, verifier string) error {
+	s.codeVerifier = verifier
+	return nil
+}
+
+func (s *mockSecretStore) GetCodeVerifier(*http.Request) (string, error) {
+	return s.codeVerifier, nil
+}
+
+func (s *mockSecretStore) DeleteCodeVerifier(*http.Request) {
+	s.codeVerifier = ""
+}
+
+func (s *mockSecretStore) SetReturnTo(_ *http.Request, returnTo string) error {
+	s.returnTo = returnTo
+	return nil
+}
+
+func (s *mockSecretStore) GetReturnTo(*http.Request) (string, error) {
+	return s.returnTo, nil
+}
+
+func (s *mockSecretStore) DeleteReturnTo(*http.Request) {
+	s.returnTo = ""
+}
+
+const (
+	testClientID    = "test-client-id"
+	testCode        = "test-code"
+	testAccessToken = "test-access-token"
+	testSubject     = "018d21f2-0b8d-756a-84f0-13b942a2bae5"
+)
+
+// newTestService starts a mock service server that serves the login and
+// callback routes at "/login" and "/callback" with a Handler of the given
+// configuration, and a mock SAMS server to authenticate against. The issuer,
+// client ID, requested scopes, redirect URI and failure handler are set by
+// newTestService. It returns the URL of the mock service server.
+func newTestService(t *testing.T, config Config, success http.Handler) string {
+	t.Helper()
+
 	userinfo, err := json.Marshal(
 		map[string]any{
 			"sub":            testSubject,
-			"name":           testName,
-			"email":          testEmail,
+			"name":           "John Doe",
+			"email":          "john.doe@example.com",
 			"email_verified": true,
 			"picture":        "https://example.com/avatar.jpg",
-			"created_at":     someday,
+			"created_at":     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
 	)
 	require.NoError(t, err)
 
-	t.Run("successfully auth flow", func(t *testing.T) {
-		// Set up the mock service server.
-		mux := http.NewServeMux()
-		mockServiceServer := httptest.NewServer(mux)
-		t.Cleanup(func() { mockServiceServer.Close() })
-		redirectURI := mockServiceServer.URL + "/callback"
+	// Set up the mock service server.
+	mux := http.NewServeMux()
+	mockServiceServer := httptest.NewServer(mux)
+	t.Cleanup(func() { mockServiceServer.Close() })
+	redirectURI := mockServiceServer.URL + "/callback"
+
+	// Set up the mock SAMS server.
+	mockSAMSServer := newMockServer(t, redirectURI, testClientID, testSubject, testCode, testAccessToken, userinfo)
+
+	// Set up auth handlers for the mock service server.
+	config.Issuer = mockSAMSServer.URL
+	config.ClientID = testClientID
+	config.RequestScopes = []scopes.Scope{scopes.OpenID, scopes.Profile, scopes.Email}
+	config.RedirectURI = redirectURI
+	config.FailureHandler = DefaultFailureHandler
+	h, err := NewHandler(config)
+	require.NoError(t, err)
+	mux.Handle("/login", h.LoginHandler())
+	mux.Handle("/callback", h.CallbackHandler(success))
+	return mockServiceServer.URL
+}
 
-		// Set up the mock SAMS server.
-		mockSAMSServer := newMockServer(t, redirectURI, testClientID, testSubject, testCode, testAccessToken, userinfo)
+// getBody returns the status code and body of the response to a GET request to
+// the given URL.
+func getBody(t *testing.T, url string) (int, string) {
+	t.Helper()
 
-		// Set up auth handlers for the mock service server.
-		h, err := NewHandler(
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("successfully auth flow", func(t *testing.T) {
+		serviceURL := newTestService(t,
 			Config{
-				Issuer:         mockSAMSServer.URL,
-				ClientID:       testClientID,
-				ClientSecret:   "test-client-secret",
-				RequestScopes:  []scopes.Scope{scopes.OpenID, scopes.Profile, scopes.Email},
-				RedirectURI:    redirectURI,
-				FailureHandler: DefaultFailureHandler,
-				SecretStore:    &mockSecretStore{},
+				ClientSecret: "test-client-secret",
+				SecretStore:  &mockSecretStore{},
 			},
-		)
-		require.NoError(t, err)
-
-		mux.Handle("/login", h.LoginHandler())
-		mux.Handle("/callback", h.CallbackHandler(
 			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				userInfo := UserInfoFromContext(r.Context())
 				assert.NotNil(t, userInfo.Token)
@@ -216,52 +284,211 @@ func TestHandler(t *testing.T) {
 				err := json.NewEncoder(w).Encode(userInfo)
 				require.NoError(t, err)
 			}),
-		))
+		)
 
 		// Simulate authentication flow.
-		client := &http.Client{}
-		resp, err := client.Get(mockServiceServer.URL + "/login?prompt=login&prompt_auth=github")
-		require.NoError(t, err)
-		respBody, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		statusCode, body := getBody(t, serviceURL+"/login?prompt=login&prompt_auth=github")
+		assert.Equal(t, http.StatusOK, statusCode)
 		autogold.Expect(`{"sub":"018d21f2-0b8d-756a-84f0-13b942a2bae5","name":"John Doe","email":"john.doe@example.com","email_verified":true,"picture":"https://example.com/avatar.jpg","created_at":"2021-01-01T00:00:00Z"}
-`).Equal(t, string(respBody))
+`).Equal(t, body)
 	})
 
 	t.Run("failed to set state", func(t *testing.T) {
-		// Set up the mock service server.
-		mux := http.NewServeMux()
-		mockServiceServer := httptest.NewServer(mux)
-		t.Cleanup(func() { mockServiceServer.Close() })
-		redirectURI := mockServiceServer.URL + "/callback"
-
-		// Set up the mock SAMS server.
-		mockSAMSServer := newMockServer(t, redirectURI, testClientID, testSubject, testCode, testAccessToken, userinfo)
-
-		// Set up auth handlers for the mock service server.
-		h, err := NewHandler(
+		serviceURL := newTestService(t,
 			Config{
-				Issuer:         mockSAMSServer.URL,
-				ClientID:       testClientID,
-				ClientSecret:   "test-client-secret",
-				RequestScopes:  []scopes.Scope{scopes.OpenID, scopes.Profile, scopes.Email},
-				RedirectURI:    redirectURI,
-				FailureHandler: DefaultFailureHandler,
-				SecretStore:    &mockSecretStore{setStateError: errors.New("failed to set state")},
+				ClientSecret: "test-client-secret",
+				SecretStore:  &mockSecretStore{setStateError: errors.New("failed to set state")},
 			},
+			http.NotFoundHandler(),
 		)
-		require.NoError(t, err)
-
-		mux.Handle("/login", h.LoginHandler())
 
 		// Simulate authentication flow.
-		client := &http.Client{}
-		resp, err := client.Get(mockServiceServer.URL + "/login?prompt=login&prompt_auth=github")
-		require.NoError(t, err)
-		respBody, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-		autogold.Expect("set state: failed to set state\n").Equal(t, string(respBody))
+		statusCode, body := getBody(t, serviceURL+"/login?prompt=login&prompt_auth=github")
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+		autogold.Expect("set state: failed to set state\n").Equal(t, body)
+	})
+}
+
+// tamperingSecretStore replaces the stored code verifier, as if the code was
+// intercepted and exchanged by someone else.
+type tamperingSecretStore struct {
+	mockSecretStore
+}
+
+func (s *tamperingSecretStore) GetCodeVerifier(*http.Request) (string, error) {
+	return oauth2.GenerateVerifier(), nil
+}
+
+// legacySecretStore only implements SecretStore, without the optional
+// CodeVerifierStore and ReturnToStore.
+type legacySecretStore struct {
+	SecretStore
+}
+
+func TestHandlerPKCE(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		clientSecret sams.Secret
+		disablePKCE  bool
+		secretStore  SecretStore
+
+		wantStatusCode int
+		wantBody       autogold.Value
+	}{{
+		name:           "confidential client",
+		clientSecret:   "test-client-secret",
+		secretStore:    &mockSecretStore{},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect("018d21f2-0b8d-756a-84f0-13b942a2bae5"),
+	}, {
+		name:           "public client",
+		secretStore:    &mockSecretStore{},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect("018d21f2-0b8d-756a-84f0-13b942a2bae5"),
+	}, {
+		name:           "PKCE disabled",
+		clientSecret:   "test-client-secret",
+		disablePKCE:    true,
+		secretStore:    &mockSecretStore{},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect("018d21f2-0b8d-756a-84f0-13b942a2bae5"),
+	}, {
+		name:           "secret store without PKCE support",
+		clientSecret:   "test-client-secret",
+		secretStore:    legacySecretStore{&mockSecretStore{}},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect("018d21f2-0b8d-756a-84f0-13b942a2bae5"),
+	}, {
+		name:           "mismatched code verifier",
+		clientSecret:   "test-client-secret",
+		secretStore:    &tamperingSecretStore{},
+		wantStatusCode: http.StatusInternalServerError,
+		wantBody:       autogold.Expect("exchange token: oauth2: \"invalid_grant\"\n"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceURL := newTestService(t,
+				Config{
+					ClientSecret: tc.clientSecret,
+					DisablePKCE:  tc.disablePKCE,
+					SecretStore:  tc.secretStore,
+				},
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(UserInfoFromContext(r.Context()).ID))
+				}),
+			)
+
+			statusCode, body := getBody(t, serviceURL+"/login?prompt=login&prompt_auth=github")
+			assert.Equal(t, tc.wantStatusCode, statusCode)
+			tc.wantBody.Equal(t, body)
+		})
+	}
+
+	for _, tc := range []struct {
+		name        string
+		disablePKCE bool
+		secretStore SecretStore
+	}{{
+		name:        "public client requires PKCE",
+		disablePKCE: true,
+		secretStore: &mockSecretStore{},
+	}, {
+		name:        "public client requires secret store with PKCE support",
+		secretStore: legacySecretStore{&mockSecretStore{}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewHandler(Config{
+				FailureHandler: DefaultFailureHandler,
+				SecretStore:    tc.secretStore,
+				DisablePKCE:    tc.disablePKCE,
+			})
+			assert.EqualError(t, err, "missing ClientSecret, which is required when PKCE is disabled or the SecretStore does not implement CodeVerifierStore")
+		})
+	}
+}
+
+// revalidatedReturnToStore returns a return-to URL that is no longer allowed,
+// as if the configuration has changed since the login.
+type revalidatedReturnToStore struct {
+	mockSecretStore
+}
+
+func (s *revalidatedReturnToStore) GetReturnTo(*http.Request) (string, error) {
+	return "/admin", nil
+}
+
+func TestHandlerReturnTo(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		returnTo    string
+		secretStore SecretStore
+
+		wantStatusCode int
+		wantBody       autogold.Value
+	}{{
+		name:           "allowed return-to URL",
+		returnTo:       "/app/settings?tab=profile",
+		secretStore:    &mockSecretStore{},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect("/app/settings?tab=profile"),
+	}, {
+		name:           "no return-to URL",
+		secretStore:    &mockSecretStore{},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect(""),
+	}, {
+		name:           "stale return-to URL from an abandoned login",
+		secretStore:    &mockSecretStore{returnTo: "/app/stale"},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect(""),
+	}, {
+		name:           "disallowed return-to URL",
+		returnTo:       "https://evil.com/app/",
+		secretStore:    &mockSecretStore{},
+		wantStatusCode: http.StatusBadRequest,
+		wantBody:       autogold.Expect("invalid return-to URL \"https://evil.com/app/\": origin \"https://evil.com\" is not allowed\n"),
+	}, {
+		name:           "return-to URL no longer allowed upon callback",
+		returnTo:       "/app/settings",
+		secretStore:    &revalidatedReturnToStore{},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect(""),
+	}, {
+		name:           "secret store without return-to support",
+		returnTo:       "/app/settings",
+		secretStore:    legacySecretStore{&mockSecretStore{}},
+		wantStatusCode: http.StatusOK,
+		wantBody:       autogold.Expect(""),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{
+				ClientSecret: "test-client-secret",
+				SecretStore:  tc.secretStore,
+			}
+			if _, ok := tc.secretStore.(ReturnToStore); ok {
+				config.ReturnTo = ReturnToConfig{AllowedPaths: []string{"/app/"}}
+			}
+			serviceURL := newTestService(t, config,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(ReturnToFromContext(r.Context())))
+				}),
+			)
+
+			statusCode, body := getBody(t, serviceURL+"/login?prompt=login&prompt_auth=github&return_to="+url.QueryEscape(tc.returnTo))
+			assert.Equal(t, tc.wantStatusCode, statusCode)
+			tc.wantBody.Equal(t, body)
+			if store, ok := tc.secretStore.(*mockSecretStore); ok {
+				assert.Empty(t, store.returnTo, "return-to URL should be one-time use")
+			}
+		})
+	}
+
+	t.Run("ReturnTo requires secret store with return-to support", func(t *testing.T) {
+		_, err := NewHandler(Config{
+			ClientSecret:   "test-client-secret",
+			FailureHandler: DefaultFailureHandler,
+			SecretStore:    legacySecretStore{&mockSecretStore{}},
+			ReturnTo:       ReturnToConfig{AllowedPaths: []string{"/app/"}},
+		})
+		assert.EqualError(t, err, "ReturnTo is configured, but the SecretStore does not implement ReturnToStore")
 	})
 }
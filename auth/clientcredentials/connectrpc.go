@@ -6,6 +6,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/internal/connectutil"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"go.opentelemetry.io/otel/attribute"
@@ -20,6 +21,7 @@ type Interceptor struct {
 	logger       log.Logger
 	introspector TokenIntrospector
 	extension    *protoimpl.ExtensionInfo
+	opts         options
 }
 
 // NewInterceptor creates a serverside ConnectRPC interceptor that ensures every
@@ -50,16 +52,20 @@ type Interceptor struct {
 // proto bindings. This variable should be provided to NewInterceptor to allow
 // it to identify where to source the required scopes from.
 //
+// Tokens bound to a user are rejected unless WithUserTokens is provided.
+//
 // The provided logger is used to record internal-server errors.
 func NewInterceptor(
 	logger log.Logger,
 	introspector TokenIntrospector,
 	methodOptionsRequiredScopesExtension *protoimpl.ExtensionInfo,
+	opts ...Option,
 ) *Interceptor {
 	return &Interceptor{
 		logger:       logger.Scoped("clientcredentials"),
 		introspector: introspector,
 		extension:    methodOptionsRequiredScopesExtension,
+		opts:         newOptions(opts),
 	}
 }
 
@@ -72,7 +78,7 @@ func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 		}
 		requiredScopes, err := extractSchemaRequiredScopes(req.Spec(), i.extension)
 		if err != nil {
-			return nil, connectInternalError(ctx, i.logger, err, "internal schema error") // invalid schema is internal error
+			return nil, connectutil.InternalError(ctx, i.logger, err, "internal schema error") // invalid schema is internal error
 		}
 		info, err := i.requireScope(ctx, req.Header(), requiredScopes)
 		if err != nil {
@@ -95,7 +101,7 @@ func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) co
 		}
 		requiredScopes, err := extractSchemaRequiredScopes(conn.Spec(), i.extension)
 		if err != nil {
-			return connectInternalError(ctx, i.logger, err, "internal schema error") // invalid schema is internal error
+			return connectutil.InternalError(ctx, i.logger, err, "internal schema error") // invalid schema is internal error
 		}
 		info, err := i.requireScope(ctx, conn.RequestHeader(), requiredScopes)
 		if err != nil {
@@ -127,20 +133,30 @@ func (i *Interceptor) requireScope(ctx context.Context, headers http.Header, req
 
 	result, err := i.introspector.IntrospectToken(ctx, token)
 	if err != nil {
-		return nil, connectInternalError(ctx, i.logger, err, "unable to validate token")
+		return nil, connectutil.InternalError(ctx, i.logger, err, "unable to validate token")
 	}
-	if result.UserID != "" {
-		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("a user-scoped token is not allowed"))
+	if err = i.opts.checkUserToken(result); err != nil {
+		if errors.Is(err, errUserTokenNotAllowed) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		connectutil.LoggerWithTrace(ctx, i.logger).Warn("attempt to authenticate using SAMS token of a service that is not allowed",
+			log.String("client", result.ClientID),
+			log.String("userID", result.UserID),
+			log.Error(err))
+		span.SetAttributes(attribute.String("full_error", err.Error()))
+		return nil, connect.NewError(connect.CodePermissionDenied, errTokenServiceNotAllowed)
 	}
 
 	span.SetAttributes(
 		attribute.String("client_id", result.ClientID),
+		attribute.String("user_id", result.UserID),
 		attribute.String("token_expires_at", result.ExpiresAt.String()),
 		attribute.StringSlice("token_scopes", scopes.ToStrings(result.Scopes)))
 	info := &ClientInfo{
 		ClientID:       result.ClientID,
 		TokenExpiresAt: result.ExpiresAt,
 		TokenScopes:    result.Scopes,
+		UserID:         result.UserID,
 	}
 
 	// Active encapsulates whether the token is active, including expiration.
@@ -181,33 +197,3 @@ func extractSchemaRequiredScopes(spec connect.Spec, extension *protoimpl.Extensi
 	}
 	return requiredScopes, nil
 }
-
-// connectInternalError logs an error, adds it to the trace, and returns a connect
-// error with a safe message.
-func connectInternalError(ctx context.Context, logger log.Logger, err error, safeMsg string) error {
-	span := trace.SpanFromContext(ctx)
-	span.SetAttributes(
-		attribute.String("safe_msg", safeMsg),
-		attribute.String("full_error", err.Error()))
-	span.SetStatus(otelcodes.Error, err.Error())
-
-	logger = logger.WithTrace(log.TraceContext{
-		TraceID: trace.SpanContextFromContext(ctx).TraceID().String(),
-		SpanID:  trace.SpanContextFromContext(ctx).SpanID().String(),
-	}).AddCallerSkip(1)
-
-	// Log at different levels and return different codes depending on the type
-	// of this unexpected error.
-	if errors.Is(err, context.Canceled) {
-		code := connect.CodeCanceled
-		logger.Warn(safeMsg,
-			log.String("code", code.String()),
-			log.Error(err))
-		return connect.NewError(code, errors.New(safeMsg))
-	}
-	code := connect.CodeInternal
-	logger.Error(safeMsg,
-		log.String("code", code.String()),
-		log.Error(err))
-	return connect.NewError(code, errors.New(safeMsg))
-}
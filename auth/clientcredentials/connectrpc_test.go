@@ -16,6 +16,7 @@ import (
 	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -24,6 +25,7 @@ func TestInterceptor(t *testing.T) {
 	for _, tc := range []struct {
 		name  string
 		token *sams.IntrospectTokenResponse
+		opts  []Option
 
 		// doRPC, if nil, tests against UsersService.GetUser()
 		doRPC func(svc clientsv1connect.UsersServiceClient) error
@@ -98,6 +100,39 @@ func TestInterceptor(t *testing.T) {
 		},
 		wantError: autogold.Expect("unauthenticated: a user-scoped token is not allowed"),
 		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name: "SAT token with userID accepted",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"profile"},
+		},
+		opts:      []Option{WithUserTokens()},
+		wantError: autogold.Expect(nil), // should not error!
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name: "SAT token with userID without service scopes",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"profile"},
+		},
+		opts:      []Option{WithUserTokens(services.Analytics)},
+		wantError: autogold.Expect("permission_denied: token service is not allowed"),
+		wantLogs:  autogold.Expect([]string{"attempt to authenticate using SAMS token of a service that is not allowed"}),
+	}, {
+		name: "SAT token with userID of disallowed service",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"profile", "analytics::analytics::read", "dotcom::user::read"},
+		},
+		opts:      []Option{WithUserTokens(services.Analytics)},
+		wantError: autogold.Expect("permission_denied: token service is not allowed"),
+		wantLogs:  autogold.Expect([]string{"attempt to authenticate using SAMS token of a service that is not allowed"}),
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			logger, exportLogs := logtest.Captured(t)
@@ -107,6 +142,7 @@ func TestInterceptor(t *testing.T) {
 					response: tc.token,
 				},
 				clientsv1.E_SamsRequiredScopes,
+				tc.opts...,
 			)
 			mux := http.NewServeMux()
 			mux.Handle(
@@ -19,6 +19,9 @@ type ClientInfo struct {
 	ClientID       string
 	TokenExpiresAt time.Time
 	TokenScopes    scopes.Scopes
+	// UserID is the external ID of the user the token is bound to, which is only
+	// set for user-bound tokens accepted with WithUserTokens.
+	UserID string
 }
 
 // LogFields represents a standard log representation of a client, for use in
@@ -32,11 +35,13 @@ func (c *ClientInfo) LogFields() []log.Field {
 		log.String("client.clientID", c.ClientID),
 		log.Time("client.tokenExpiresAt", c.TokenExpiresAt),
 		log.String("client.tokenScopes", strings.Join(scopes.ToStrings(c.TokenScopes), " ")),
+		log.String("client.userID", c.UserID),
 	}
 }
 
 // ClientInfoFromContext returns client info from the given context. This is
-// generally set by clientcredentials.Interceptor.
+// generally set by clientcredentials.Interceptor and
+// clientcredentials.HTTPAuthenticator.
 func ClientInfoFromContext(ctx context.Context) *ClientInfo {
 	return ctx.Value(clientInfoKey).(*ClientInfo)
 }
@@ -14,6 +14,7 @@ import (
 type HTTPAuthenticator struct {
 	logger       log.Logger
 	introspector TokenIntrospector
+	opts         options
 }
 
 // NewHTTPAuthenticator provides a factor for auth middleware that uses SAMS
@@ -21,16 +22,20 @@ type HTTPAuthenticator struct {
 //
 // If you are using ConnectRPC, use clientcredentials.NewInterceptor() instead.
 // HTTPAuthenticator should only be used for non-ConnectRPC APIs.
-func NewHTTPAuthenticator(logger log.Logger, introspector TokenIntrospector) *HTTPAuthenticator {
+//
+// Tokens bound to a user are rejected unless WithUserTokens is provided.
+func NewHTTPAuthenticator(logger log.Logger, introspector TokenIntrospector, opts ...Option) *HTTPAuthenticator {
 	return &HTTPAuthenticator{
 		logger:       logger,
 		introspector: introspector,
+		opts:         newOptions(opts),
 	}
 }
 
 // RequireScopes performs an authorization check on the incoming HTTP request.
 // It will return a 401 if the request does not have a valid SAMS access token,
 // or a 403 if the token is valid but is missing ANY of the required scopes.
+// The ClientInfo of the token is available to next with ClientInfoFromContext.
 func (a *HTTPAuthenticator) RequireScopes(requiredScopes scopes.Scopes, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -68,11 +73,19 @@ func (a *HTTPAuthenticator) RequireScopes(requiredScopes scopes.Scopes, next htt
 			return
 		}
 
-		if result.UserID != "" {
-			logger.Warn("attempt to authenticate using SAMS token with user ID",
+		if err = a.opts.checkUserToken(result); err != nil {
+			if errors.Is(err, errUserTokenNotAllowed) {
+				logger.Warn("attempt to authenticate using SAMS token with user ID",
+					log.String("client", result.ClientID),
+					log.String("userID", result.UserID))
+				http.Error(w, "Forbidden: User tokens not allowed", http.StatusForbidden)
+				return
+			}
+			logger.Warn("attempt to authenticate using SAMS token of a service that is not allowed",
 				log.String("client", result.ClientID),
-				log.String("userID", result.UserID))
-			http.Error(w, "Forbidden: User tokens not allowed", http.StatusForbidden)
+				log.String("userID", result.UserID),
+				log.Error(err))
+			http.Error(w, "Forbidden: Token service not allowed", http.StatusForbidden)
 			return
 		}
 
@@ -87,6 +100,11 @@ func (a *HTTPAuthenticator) RequireScopes(requiredScopes scopes.Scopes, next htt
 			}
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(WithClientInfo(ctx, &ClientInfo{
+			ClientID:       result.ClientID,
+			TokenExpiresAt: result.ExpiresAt,
+			TokenScopes:    result.Scopes,
+			UserID:         result.UserID,
+		})))
 	})
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/log/logtest"
 	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 )
@@ -20,6 +21,7 @@ func TestHTTPAuthenticator(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
 		token     *sams.IntrospectTokenResponse
+		opts      []Option
 		wantError autogold.Value
 		wantLogs  autogold.Value
 	}{{
@@ -62,18 +64,76 @@ func TestHTTPAuthenticator(t *testing.T) {
 		},
 		wantError: autogold.Expect("Forbidden: User tokens not allowed\n"),
 		wantLogs:  autogold.Expect([]string{"attempt to authenticate using SAMS token with user ID"}),
+	}, {
+		name: "SAT token with userID accepted",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"profile"},
+		},
+		opts:      []Option{WithUserTokens()},
+		wantError: autogold.Expect("OK (user: test-user)"),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name: "SAT token with userID of allowed service without required scope",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"analytics::analytics::read"},
+		},
+		opts:      []Option{WithUserTokens(services.Analytics)},
+		wantError: autogold.Expect("Forbidden: Missing required scope\n"),
+		wantLogs:  autogold.Expect([]string{"attempt to authenticate using SAMS token without required scope"}),
+	}, {
+		name: "SAT token with userID of allowed service and OpenID Connect scopes",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"openid", "profile", "analytics::analytics::read"},
+		},
+		opts:      []Option{WithUserTokens(services.Analytics)},
+		wantError: autogold.Expect("OK (user: test-user)"),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name: "SAT token with userID without service scopes",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"profile"},
+		},
+		opts:      []Option{WithUserTokens(services.Analytics)},
+		wantError: autogold.Expect("Forbidden: Token service not allowed\n"),
+		wantLogs:  autogold.Expect([]string{"attempt to authenticate using SAMS token of a service that is not allowed"}),
+	}, {
+		name: "SAT token with userID of disallowed service",
+		token: &sams.IntrospectTokenResponse{
+			Active:   true,
+			ClientID: "test-client",
+			UserID:   "test-user",
+			Scopes:   scopes.Scopes{"profile", "analytics::analytics::read", "dotcom::user::read"},
+		},
+		opts:      []Option{WithUserTokens(services.Analytics)},
+		wantError: autogold.Expect("Forbidden: Token service not allowed\n"),
+		wantLogs:  autogold.Expect([]string{"attempt to authenticate using SAMS token of a service that is not allowed"}),
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			logger, exportLogs := logtest.Captured(t)
 			authenticator := NewHTTPAuthenticator(logger, &mockTokenIntrospector{
 				response: tc.token,
-			})
+			}, tc.opts...)
 			mux := http.NewServeMux()
 			mux.Handle("/", authenticator.RequireScopes(
 				scopes.Scopes{scopes.Profile},
 				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusOK)
 					_, _ = w.Write([]byte(http.StatusText(http.StatusOK)))
+					if userID := ClientInfoFromContext(r.Context()).UserID; userID != "" {
+						_, _ = w.Write([]byte(" (user: " + userID + ")"))
+					}
 				}),
 			))
 			srv := httptest.NewServer(mux)
@@ -0,0 +1,82 @@
+package clientcredentials
+
+import (
+	"slices"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+// Option configures NewInterceptor and NewHTTPAuthenticator.
+type Option func(*options)
+
+type options struct {
+	allowUserTokens bool
+	// allowedServices is the list of services whose user-bound tokens are
+	// accepted, or empty to accept user-bound tokens of any service.
+	allowedServices []services.Service
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithUserTokens accepts tokens that are bound to a user, e.g. service access
+// tokens issued to users, in addition to client credentials tokens. The user
+// is available as ClientInfo.UserID. Required scopes are enforced the same way.
+//
+// If allowedServices is not empty, only user-bound tokens with at least one
+// scope of the services, and whose scopes all belong to one of the services,
+// are accepted. OpenID Connect scopes like "openid" and "profile" are ignored.
+// Service access tokens only carry scopes of the service they are issued for.
+func WithUserTokens(allowedServices ...services.Service) Option {
+	return func(o *options) {
+		o.allowUserTokens = true
+		o.allowedServices = allowedServices
+	}
+}
+
+// checkUserToken returns an error if the user-bound token is not accepted. It
+// is a no-op for tokens that are not bound to a user.
+func (o options) checkUserToken(result *sams.IntrospectTokenResponse) error {
+	if result.UserID == "" {
+		return nil
+	}
+	if !o.allowUserTokens {
+		return errUserTokenNotAllowed
+	}
+	if len(o.allowedServices) == 0 {
+		return nil
+	}
+	var serviceScopes int
+	for _, scope := range result.Scopes {
+		if slices.Contains(openIDConnectScopes, scope) {
+			continue // not associated with any service
+		}
+		parsed, valid := scopes.ParseScope(scope)
+		if !valid || !slices.Contains(o.allowedServices, parsed.Service) {
+			return errors.Wrapf(errTokenServiceNotAllowed, "scope %q", scope)
+		}
+		serviceScopes++
+	}
+	if serviceScopes == 0 {
+		return errors.Wrap(errTokenServiceNotAllowed, "no service scopes")
+	}
+	return nil
+}
+
+// openIDConnectScopes are the scopes that may be granted to user-bound tokens
+// in addition to service scopes.
+var openIDConnectScopes = []scopes.Scope{scopes.OpenID, scopes.Profile, scopes.Email, scopes.OfflineAccess}
+
+var (
+	errUserTokenNotAllowed    = errors.New("a user-scoped token is not allowed")
+	errTokenServiceNotAllowed = errors.New("token service is not allowed")
+)
@@ -9,6 +9,7 @@ type contextKey int
 const (
 	errorKey contextKey = iota
 	userInfoKey
+	returnToKey
 )
 
 // ErrorFromContext returns the error from the given context.
@@ -30,3 +31,16 @@ func UserInfoFromContext(ctx context.Context) *UserInfo {
 func WithUserInfo(ctx context.Context, userInfo *UserInfo) context.Context {
 	return context.WithValue(ctx, userInfoKey, userInfo)
 }
+
+// ReturnToFromContext returns the validated return-to URL requested upon login
+// from the given context, or an empty string if there is none or it is no longer
+// allowed by Config.ReturnTo. This is set by Handler.CallbackHandler.
+func ReturnToFromContext(ctx context.Context) string {
+	returnTo, _ := ctx.Value(returnToKey).(string)
+	return returnTo
+}
+
+// WithReturnTo returns a new context with the given return-to URL.
+func WithReturnTo(ctx context.Context, returnTo string) context.Context {
+	return context.WithValue(ctx, returnToKey, returnTo)
+}
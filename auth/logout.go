@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
+)
+
+// LogoutOptions configures Handler.LogoutHandler.
+type LogoutOptions struct {
+	// ClearSession is called to clear the local state of the user, e.g. delete
+	// the session cookie and the session data, and returns the SAMS session to
+	// sign out, which may be nil if there is none.
+	ClearSession func(w http.ResponseWriter, r *http.Request) (*LogoutSession, error)
+	// Sessions is used to sign out the SAMS session returned by ClearSession,
+	// e.g. *sams.SessionsServiceV1, which requires the "sams::session::write"
+	// scope.
+	//
+	// The default of nil does not sign out the SAMS session via the Sessions API.
+	Sessions SessionsService
+	// OnSignOutSessionError is called when signing out the SAMS session via
+	// Sessions fails. The local state of the user has already been cleared by
+	// then, so the logout always continues to the redirect, which still signs
+	// the user out of SAMS unless DisableRPInitiatedLogout is set.
+	//
+	// The default of nil ignores the error.
+	OnSignOutSessionError func(r *http.Request, err error)
+	// PostLogoutRedirectURI is the absolute URL to redirect to after the user has
+	// signed out. It MUST exact-match one of the pre-registered post-logout
+	// redirect URIs on SAMS.
+	PostLogoutRedirectURI string
+	// ValidateRedirect validates the URL requested by the
+	// "post_logout_redirect_uri" query parameter, which is only used in place of
+	// PostLogoutRedirectURI when it returns no error. The URL is always absolute
+	// with a "http" or "https" scheme, and MUST be pre-registered on SAMS.
+	//
+	// The default of nil only allows PostLogoutRedirectURI itself.
+	ValidateRedirect func(u *url.URL) error
+	// DisableRPInitiatedLogout disables redirecting the user to the issuer's
+	// "end_session_endpoint" (OpenID Connect RP-Initiated Logout), and redirects
+	// to the post-logout redirect URI directly.
+	DisableRPInitiatedLogout bool
+}
+
+func (opts LogoutOptions) Validate() error {
+	if opts.ClearSession == nil {
+		return errors.New("missing ClearSession")
+	}
+	if opts.PostLogoutRedirectURI == "" {
+		return errors.New("missing PostLogoutRedirectURI")
+	}
+	if _, err := parseRedirectURI(opts.PostLogoutRedirectURI); err != nil {
+		return errors.Wrap(err, "invalid PostLogoutRedirectURI")
+	}
+	return nil
+}
+
+// LogoutSession is the SAMS session of a user that is logging out.
+type LogoutSession struct {
+	// SessionID is the ID of the SAMS session to sign out via
+	// LogoutOptions.Sessions. It is skipped when empty.
+	SessionID string
+	// UserID is the external ID of the user that the SAMS session belongs to.
+	UserID string
+	// IDToken is the raw ID token issued to the user, which is sent as the
+	// "id_token_hint" to the issuer's "end_session_endpoint".
+	IDToken string
+}
+
+// SessionsService is the interface for signing out SAMS sessions, which is
+// implemented by *sams.SessionsServiceV1.
+type SessionsService interface {
+	SignOutSession(ctx context.Context, sessionID, userID string) error
+}
+
+var _ SessionsService = (*sams.SessionsServiceV1)(nil)
+
+// LogoutHandler returns an HTTP handler that clears the local state of the user
+// with LogoutOptions.ClearSession, signs out the SAMS session if configured,
+// and redirects the user to the issuer's "end_session_endpoint" to sign out of
+// SAMS, which then redirects the user to the post-logout redirect URI. If the
+// issuer does not advertise an "end_session_endpoint", the user is redirected
+// to the post-logout redirect URI directly.
+//
+// Only POST requests are accepted, so that other sites cannot sign the user out
+// with a link or an image (logout CSRF). Other methods are rejected with 405
+// Method Not Allowed.
+//
+// The "post_logout_redirect_uri" query parameter may request a different
+// post-logout redirect URI, which is rejected unless allowed by
+// LogoutOptions.ValidateRedirect.
+//
+// It panics if the options are invalid, see LogoutOptions.Validate.
+func (h *Handler) LogoutHandler(opts LogoutOptions) http.Handler {
+	if err := opts.Validate(); err != nil {
+		panic(errors.Wrap(err, "invalid LogoutOptions"))
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			ctx = WithError(ctx, &Error{
+				StatusCode: http.StatusMethodNotAllowed,
+				Cause:      errors.Errorf("method %s is not allowed", r.Method),
+			})
+			h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		redirectURI, err := opts.redirectURI(r)
+		if err != nil {
+			ctx = WithError(ctx, &Error{
+				StatusCode: http.StatusBadRequest,
+				Cause:      errors.Wrap(err, "invalid post-logout redirect URI"),
+			})
+			h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		// Discover the end session endpoint before clearing anything, so that we do
+		// not leave the user half-way signed out.
+		var endSessionURL *url.URL
+		if !opts.DisableRPInitiatedLogout {
+			endSessionURL, err = h.endSessionEndpoint(ctx)
+			if err != nil {
+				ctx = WithError(ctx, &Error{
+					StatusCode: http.StatusInternalServerError,
+					Cause:      err,
+				})
+				h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+
+		session, err := opts.ClearSession(w, r)
+		if err != nil {
+			ctx = WithError(ctx, &Error{
+				StatusCode: http.StatusInternalServerError,
+				Cause:      errors.Wrap(err, "clear session"),
+			})
+			h.config.FailureHandler.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		if session == nil {
+			session = &LogoutSession{}
+		}
+
+		// The local state has been cleared, so do not fail the logout from here on,
+		// which would leave the user half-way signed out.
+		if opts.Sessions != nil && session.SessionID != "" {
+			err = opts.Sessions.SignOutSession(ctx, session.SessionID, session.UserID)
+			if err != nil && opts.OnSignOutSessionError != nil {
+				opts.OnSignOutSessionError(r, errors.Wrap(err, "sign out session"))
+			}
+		}
+
+		// Redirect with 303 See Other, so that the browser follows with a GET.
+		if endSessionURL == nil {
+			http.Redirect(w, r, redirectURI.String(), http.StatusSeeOther)
+			return
+		}
+
+		params := endSessionURL.Query()
+		params.Set("client_id", h.config.ClientID)
+		params.Set("post_logout_redirect_uri", redirectURI.String())
+		if session.IDToken != "" {
+			params.Set("id_token_hint", session.IDToken)
+		}
+		endSessionURL.RawQuery = params.Encode()
+		http.Redirect(w, r, endSessionURL.String(), http.StatusSeeOther)
+	})
+}
+
+// redirectURI returns the post-logout redirect URI for the request.
+func (opts LogoutOptions) redirectURI(r *http.Request) (*url.URL, error) {
+	defaultURI, err := parseRedirectURI(opts.PostLogoutRedirectURI)
+	if err != nil {
+		return nil, err
+	}
+	requested := r.URL.Query().Get("post_logout_redirect_uri")
+	if requested == "" || requested == opts.PostLogoutRedirectURI {
+		return defaultURI, nil
+	}
+
+	u, err := parseRedirectURI(requested)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ValidateRedirect == nil {
+		return nil, errors.Errorf("%q is not allowed", requested)
+	}
+	if err = opts.ValidateRedirect(u); err != nil {
+		return nil, errors.Wrapf(err, "%q is not allowed", requested)
+	}
+	return u, nil
+}
+
+// parseRedirectURI parses the given URI, which must be an absolute "http" or
+// "https" URL without user information.
+func parseRedirectURI(uri string) (*url.URL, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("%q must be an absolute URL with a http or https scheme", uri)
+	} else if u.Host == "" {
+		return nil, errors.Errorf("%q must have a host", uri)
+	} else if u.User != nil {
+		return nil, errors.Errorf("%q must not have user information", uri)
+	}
+	return u, nil
+}
+
+// endSessionEndpoint returns the "end_session_endpoint" advertised by the
+// issuer, or nil if there is none.
+func (h *Handler) endSessionEndpoint(ctx context.Context) (*url.URL, error) {
+	p, err := oidc.NewProvider(ctx, h.config.Issuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "create new provider")
+	}
+	var claims struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err = p.Claims(&claims); err != nil {
+		return nil, errors.Wrap(err, "unmarshal provider claims")
+	}
+	if claims.EndSessionEndpoint == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(claims.EndSessionEndpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse end session endpoint")
+	}
+	return u, nil
+}
@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
+)
+
+// newMockDiscoveryServer returns a new mock server that only serves the OIDC
+// discovery document of a SAMS instance.
+func newMockDiscoveryServer(t *testing.T, endSession bool) *httptest.Server {
+	mux := http.NewServeMux()
+	s := httptest.NewServer(mux)
+	t.Cleanup(func() { s.Close() })
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		openidConfig := map[string]any{
+			"issuer":                 s.URL,
+			"authorization_endpoint": s.URL + "/oauth/authorize",
+			"token_endpoint":         s.URL + "/oauth/token",
+		}
+		if endSession {
+			openidConfig["end_session_endpoint"] = s.URL + "/oauth/logout?locale=en"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(openidConfig)
+		require.NoError(t, err)
+	})
+	return s
+}
+
+type mockSessionsService struct {
+	signedOut [][2]string
+	err       error
+}
+
+func (s *mockSessionsService) SignOutSession(_ context.Context, sessionID, userID string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.signedOut = append(s.signedOut, [2]string{sessionID, userID})
+	return nil
+}
+
+func TestLogoutHandler(t *testing.T) {
+	const (
+		testClientID              = "test-client-id"
+		testPostLogoutRedirectURI = "https://service.example.com/logged-out"
+	)
+	testSession := &LogoutSession{
+		SessionID: "session-1",
+		UserID:    "user-1",
+		IDToken:   "raw-id-token",
+	}
+
+	for _, tc := range []struct {
+		name       string
+		endSession bool
+		opts       LogoutOptions
+		query      string
+
+		wantStatusCode   int
+		wantLocation     autogold.Value
+		wantBody         autogold.Value
+		wantCleared      bool
+		wantSignedOut    [][2]string
+		wantSignOutError autogold.Value
+	}{{
+		name:       "RP-initiated logout",
+		endSession: true,
+		opts: LogoutOptions{
+			Sessions: &mockSessionsService{},
+		},
+		wantStatusCode: http.StatusSeeOther,
+		wantLocation:   autogold.Expect("/oauth/logout?client_id=test-client-id&id_token_hint=raw-id-token&locale=en&post_logout_redirect_uri=https%3A%2F%2Fservice.example.com%2Flogged-out"),
+		wantCleared:    true,
+		wantSignedOut:  [][2]string{{"session-1", "user-1"}},
+	}, {
+		name:           "no end session endpoint",
+		endSession:     false,
+		wantStatusCode: http.StatusSeeOther,
+		wantLocation:   autogold.Expect("https://service.example.com/logged-out"),
+		wantCleared:    true,
+	}, {
+		name:       "RP-initiated logout disabled",
+		endSession: true,
+		opts: LogoutOptions{
+			DisableRPInitiatedLogout: true,
+		},
+		wantStatusCode: http.StatusSeeOther,
+		wantLocation:   autogold.Expect("https://service.example.com/logged-out"),
+		wantCleared:    true,
+	}, {
+		name:           "requested redirect not allowed by default",
+		endSession:     false,
+		query:          "?post_logout_redirect_uri=" + url.QueryEscape("https://evil.example.com/"),
+		wantStatusCode: http.StatusBadRequest,
+		wantBody:       autogold.Expect("invalid post-logout redirect URI: \"https://evil.example.com/\" is not allowed\n"),
+	}, {
+		name:           "requested relative redirect",
+		endSession:     false,
+		query:          "?post_logout_redirect_uri=" + url.QueryEscape("//evil.example.com/"),
+		wantStatusCode: http.StatusBadRequest,
+		wantBody:       autogold.Expect("invalid post-logout redirect URI: \"//evil.example.com/\" must be an absolute URL with a http or https scheme\n"),
+	}, {
+		name:       "requested redirect allowed",
+		endSession: false,
+		opts: LogoutOptions{
+			ValidateRedirect: func(u *url.URL) error {
+				if u.Host != "service.example.com" {
+					return errors.New("unknown host")
+				}
+				return nil
+			},
+		},
+		query:          "?post_logout_redirect_uri=" + url.QueryEscape("https://service.example.com/bye"),
+		wantStatusCode: http.StatusSeeOther,
+		wantLocation:   autogold.Expect("https://service.example.com/bye"),
+		wantCleared:    true,
+	}, {
+		name:       "requested redirect rejected by validator",
+		endSession: false,
+		opts: LogoutOptions{
+			ValidateRedirect: func(u *url.URL) error {
+				if u.Host != "service.example.com" {
+					return errors.New("unknown host")
+				}
+				return nil
+			},
+		},
+		query:          "?post_logout_redirect_uri=" + url.QueryEscape("https://evil.example.com/"),
+		wantStatusCode: http.StatusBadRequest,
+		wantBody:       autogold.Expect("invalid post-logout redirect URI: \"https://evil.example.com/\" is not allowed: unknown host\n"),
+	}, {
+		name:       "failed to sign out session",
+		endSession: true,
+		opts: LogoutOptions{
+			Sessions: &mockSessionsService{err: sams.ErrRecordMismatch},
+		},
+		wantStatusCode:   http.StatusSeeOther,
+		wantLocation:     autogold.Expect("/oauth/logout?client_id=test-client-id&id_token_hint=raw-id-token&locale=en&post_logout_redirect_uri=https%3A%2F%2Fservice.example.com%2Flogged-out"),
+		wantCleared:      true,
+		wantSignOutError: autogold.Expect("sign out session: record mismatch"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSAMSServer := newMockDiscoveryServer(t, tc.endSession)
+			h, err := NewHandler(
+				Config{
+					Issuer:         mockSAMSServer.URL,
+					ClientID:       testClientID,
+					RedirectURI:    "https://service.example.com/callback",
+					FailureHandler: DefaultFailureHandler,
+					SecretStore:    &mockSecretStore{},
+				},
+			)
+			require.NoError(t, err)
+
+			var cleared bool
+			opts := tc.opts
+			opts.PostLogoutRedirectURI = testPostLogoutRedirectURI
+			opts.ClearSession = func(http.ResponseWriter, *http.Request) (*LogoutSession, error) {
+				cleared = true
+				return testSession, nil
+			}
+			var signOutErr error
+			opts.OnSignOutSessionError = func(_ *http.Request, err error) {
+				signOutErr = err
+			}
+			logout := h.LogoutHandler(opts)
+
+			w := httptest.NewRecorder()
+			logout.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/logout"+tc.query, nil))
+			resp := w.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantStatusCode, resp.StatusCode)
+			if tc.wantLocation != nil {
+				tc.wantLocation.Equal(t, strings.TrimPrefix(resp.Header.Get("Location"), mockSAMSServer.URL))
+			}
+			if tc.wantBody != nil {
+				tc.wantBody.Equal(t, string(body))
+			}
+			assert.Equal(t, tc.wantCleared, cleared)
+			if sessions, ok := opts.Sessions.(*mockSessionsService); ok {
+				assert.Equal(t, tc.wantSignedOut, sessions.signedOut)
+			}
+			if tc.wantSignOutError != nil {
+				require.Error(t, signOutErr)
+				tc.wantSignOutError.Equal(t, signOutErr.Error())
+			} else {
+				assert.NoError(t, signOutErr)
+			}
+		})
+	}
+
+	t.Run("method not allowed", func(t *testing.T) {
+		mockSAMSServer := newMockDiscoveryServer(t, true)
+		h, err := NewHandler(Config{
+			Issuer:         mockSAMSServer.URL,
+			ClientID:       testClientID,
+			FailureHandler: DefaultFailureHandler,
+			SecretStore:    &mockSecretStore{},
+		})
+		require.NoError(t, err)
+
+		var cleared bool
+		logout := h.LogoutHandler(LogoutOptions{
+			ClearSession: func(http.ResponseWriter, *http.Request) (*LogoutSession, error) {
+				cleared = true
+				return nil, nil
+			},
+			PostLogoutRedirectURI: testPostLogoutRedirectURI,
+		})
+
+		w := httptest.NewRecorder()
+		logout.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logout", nil))
+		resp := w.Result()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		assert.Equal(t, http.MethodPost, resp.Header.Get("Allow"))
+		assert.False(t, cleared)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		h, err := NewHandler(Config{FailureHandler: DefaultFailureHandler, SecretStore: &mockSecretStore{}})
+		require.NoError(t, err)
+
+		assert.PanicsWithError(t, "invalid LogoutOptions: missing ClearSession", func() {
+			h.LogoutHandler(LogoutOptions{PostLogoutRedirectURI: testPostLogoutRedirectURI})
+		})
+		assert.PanicsWithError(t, `invalid LogoutOptions: invalid PostLogoutRedirectURI: "/logged-out" must be an absolute URL with a http or https scheme`, func() {
+			h.LogoutHandler(LogoutOptions{
+				ClearSession:          func(http.ResponseWriter, *http.Request) (*LogoutSession, error) { return nil, nil },
+				PostLogoutRedirectURI: "/logged-out",
+			})
+		})
+	})
+}
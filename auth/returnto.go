@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ReturnToConfig configures the "return_to" query parameter of LoginHandler,
+// which is the URL to return the user to after a successful authentication.
+type ReturnToConfig struct {
+	// AllowedOrigins is the list of origins, e.g. "https://sourcegraph.com", that
+	// absolute return-to URLs may have. Relative return-to URLs, which MUST start
+	// with a single "/", are always allowed.
+	//
+	// The default of empty only allows relative return-to URLs.
+	AllowedOrigins []string
+	// AllowedPaths is the list of paths that return-to URLs may have, where a
+	// path ending with "/" also allows all paths under it, e.g. "/app/" allows
+	// "/app/settings".
+	//
+	// The default of empty allows all paths.
+	AllowedPaths []string
+}
+
+// Validate returns an error if the given return-to URL is not allowed.
+func (c ReturnToConfig) Validate(returnTo string) error {
+	// Browsers treat backslashes as forward slashes, which would turn "/\evil.com"
+	// into a protocol-relative URL.
+	if strings.Contains(returnTo, `\`) {
+		return errors.New("must not contain backslashes")
+	}
+	u, err := url.Parse(returnTo)
+	if err != nil {
+		return err
+	}
+	if u.User != nil {
+		return errors.New("must not have user information")
+	}
+
+	if u.Scheme == "" {
+		if u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+			return errors.New("relative URL must start with a single \"/\"")
+		}
+	} else {
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.New("must have a http or https scheme")
+		}
+		origin := u.Scheme + "://" + strings.ToLower(u.Host)
+		if !slices.ContainsFunc(c.AllowedOrigins, func(allowed string) bool {
+			return strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin)
+		}) {
+			return errors.Errorf("origin %q is not allowed", origin)
+		}
+	}
+
+	if len(c.AllowedPaths) == 0 {
+		return nil
+	}
+	p := u.Path
+	if p == "" {
+		p = "/"
+	}
+	// Reject paths that would be normalized into a different path, e.g.
+	// "/app/../admin", as they may escape the allowed paths.
+	if cleaned := path.Clean(p); cleaned != strings.TrimSuffix(p, "/") && cleaned != p {
+		return errors.New("path must be clean")
+	}
+	for _, allowed := range c.AllowedPaths {
+		if p == allowed || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(p, allowed)) {
+			return nil
+		}
+	}
+	return errors.Errorf("path %q is not allowed", p)
+}
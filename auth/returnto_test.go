@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturnToConfigValidate(t *testing.T) {
+	config := ReturnToConfig{
+		AllowedOrigins: []string{"https://sourcegraph.com/"},
+		AllowedPaths:   []string{"/home", "/app/"},
+	}
+	for _, tc := range []struct {
+		name     string
+		config   ReturnToConfig
+		returnTo string
+		wantErr  autogold.Value
+	}{{
+		name:     "relative",
+		returnTo: "/settings?tab=profile#top",
+	}, {
+		name:     "allowed origin",
+		config:   config,
+		returnTo: "https://SOURCEGRAPH.com/app/settings",
+	}, {
+		name:     "allowed exact path",
+		config:   config,
+		returnTo: "/home",
+	}, {
+		name:     "absolute without allowed origins",
+		returnTo: "https://sourcegraph.com/",
+		wantErr:  autogold.Expect(`origin "https://sourcegraph.com" is not allowed`),
+	}, {
+		name:     "disallowed origin",
+		config:   config,
+		returnTo: "https://evil.com/app/",
+		wantErr:  autogold.Expect(`origin "https://evil.com" is not allowed`),
+	}, {
+		name:     "disallowed scheme",
+		config:   config,
+		returnTo: "javascript:alert(1)",
+		wantErr:  autogold.Expect(`must have a http or https scheme`),
+	}, {
+		name:     "protocol-relative",
+		returnTo: "//evil.com/",
+		wantErr:  autogold.Expect(`relative URL must start with a single "/"`),
+	}, {
+		name:     "backslash",
+		returnTo: `/\evil.com`,
+		wantErr:  autogold.Expect("must not contain backslashes"),
+	}, {
+		name:     "not starting with slash",
+		returnTo: "evil.com",
+		wantErr:  autogold.Expect(`relative URL must start with a single "/"`),
+	}, {
+		name:     "user information",
+		config:   config,
+		returnTo: "https://sourcegraph.com@evil.com/",
+		wantErr:  autogold.Expect("must not have user information"),
+	}, {
+		name:     "disallowed path",
+		config:   config,
+		returnTo: "/admin",
+		wantErr:  autogold.Expect(`path "/admin" is not allowed`),
+	}, {
+		name:     "path traversal",
+		config:   config,
+		returnTo: "/app/%2e%2e/admin",
+		wantErr:  autogold.Expect("path must be clean"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate(tc.returnTo)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			tc.wantErr.Equal(t, err.Error())
+		})
+	}
+}
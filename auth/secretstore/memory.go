@@ -0,0 +1,77 @@
+package secretstore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryPurgeInterval is the minimum interval between purges of expired
+// entries, so that writes do not scan all entries every time.
+const memoryPurgeInterval = time.Minute
+
+// MemoryKV is a KV that keeps values in memory. Expired values are removed
+// lazily, and purged on writes at most once per minute.
+type MemoryKV struct {
+	// now returns the current time, it is only overridden in tests.
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	nextPurge time.Time
+}
+
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+var _ KV = (*MemoryKV)(nil)
+
+// NewMemoryKV returns a new, empty in-memory KV.
+func NewMemoryKV() *MemoryKV {
+	return &MemoryKV{
+		now:     time.Now,
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (kv *MemoryKV) Set(_ context.Context, key, value string, ttl time.Duration) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	now := kv.now()
+	// Remove expired entries so that abandoned login flows do not accumulate.
+	if !now.Before(kv.nextPurge) {
+		for k, e := range kv.entries {
+			if !now.Before(e.expiresAt) {
+				delete(kv.entries, k)
+			}
+		}
+		kv.nextPurge = now.Add(memoryPurgeInterval)
+	}
+	kv.entries[key] = memoryEntry{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (kv *MemoryKV) Get(_ context.Context, key string) (string, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	e, ok := kv.entries[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	if !kv.now().Before(e.expiresAt) {
+		delete(kv.entries, key)
+		return "", ErrNotFound
+	}
+	return e.value, nil
+}
+
+func (kv *MemoryKV) Delete(_ context.Context, key string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	delete(kv.entries, key)
+	return nil
+}
@@ -0,0 +1,208 @@
+// Package secretstore provides reference implementations of auth.SecretStore,
+// which keep the authentication secrets of each login flow in a key-value store
+// with expiry, keyed by the per-user session of the request.
+package secretstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth"
+)
+
+const (
+	defaultTTL       = 10 * time.Minute
+	defaultKeyPrefix = "sams_auth:"
+)
+
+// ErrNotFound is returned by KV when a key does not exist or has expired.
+var ErrNotFound = errors.New("key not found")
+
+// KV is the interface of a generic key-value storage backend with expiry, e.g.
+// Redis or a database table, for Store.
+type KV interface {
+	// Set sets the value of the given key, replacing any existing value, which
+	// expires after the given TTL.
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	// Get returns the value of the given key. It returns ErrNotFound if the key
+	// does not exist or has expired.
+	Get(ctx context.Context, key string) (string, error)
+	// Delete deletes the given key. It does not return an error if the key does
+	// not exist.
+	Delete(ctx context.Context, key string) error
+}
+
+// Options configures New and NewMemory.
+type Options struct {
+	// SessionID returns the ID of the per-user session of the request, e.g. from
+	// a session cookie, which MUST be the same for the login and the callback
+	// requests of a user.
+	SessionID func(r *http.Request) (string, error)
+	// TTL is the duration to keep secrets, which bounds how long a user has to
+	// complete the login flow.
+	//
+	// The default of 0 (or less) uses 10 minutes.
+	TTL time.Duration
+	// KeyPrefix is prepended to all keys in the KV.
+	//
+	// The default is "sams_auth:".
+	KeyPrefix string
+	// Logger is used to record errors that cannot be returned, i.e. failures to
+	// delete secrets.
+	//
+	// The default of nil discards them.
+	Logger log.Logger
+}
+
+func (opts Options) Validate() error {
+	if opts.SessionID == nil {
+		return errors.New("SessionID is required")
+	}
+	return nil
+}
+
+// Store is an auth.SecretStore that keeps secrets in a KV. Secrets that do not
+// exist or have expired are returned as empty strings.
+type Store struct {
+	kv   KV
+	opts Options
+}
+
+var (
+	_ auth.SecretStore       = (*Store)(nil)
+	_ auth.CodeVerifierStore = (*Store)(nil)
+	_ auth.ReturnToStore     = (*Store)(nil)
+)
+
+// New returns a Store that keeps secrets in the given KV.
+func New(kv KV, opts Options) (*Store, error) {
+	if kv == nil {
+		return nil, errors.New("KV is required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid options")
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultTTL
+	}
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = defaultKeyPrefix
+	}
+	if opts.Logger == nil {
+		opts.Logger = log.NoOp()
+	}
+	opts.Logger = opts.Logger.Scoped("secretstore")
+	return &Store{kv: kv, opts: opts}, nil
+}
+
+// NewMemory returns a Store that keeps secrets in memory, which is only
+// suitable for single-replica services as secrets are not shared between
+// replicas and are lost on restart.
+func NewMemory(opts Options) (*Store, error) {
+	return New(NewMemoryKV(), opts)
+}
+
+// key returns the KV key of the kind of secret in the session of the request.
+// Session IDs are hashed to not leak them through keys.
+func (s *Store) key(r *http.Request, kind string) (string, error) {
+	sessionID, err := s.opts.SessionID(r)
+	if err != nil {
+		return "", errors.Wrap(err, "get session ID")
+	}
+	if sessionID == "" {
+		return "", errors.New("empty session ID")
+	}
+	sum := sha256.Sum256([]byte(sessionID))
+	return s.opts.KeyPrefix + kind + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+func (s *Store) set(r *http.Request, kind, value string) error {
+	key, err := s.key(r, kind)
+	if err != nil {
+		return err
+	}
+	return s.kv.Set(r.Context(), key, value, s.opts.TTL)
+}
+
+func (s *Store) get(r *http.Request, kind string) (string, error) {
+	key, err := s.key(r, kind)
+	if err != nil {
+		return "", err
+	}
+	value, err := s.kv.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+// delete deletes the secret on a best-effort basis, it expires after the TTL
+// regardless. Failures are logged with Options.Logger.
+func (s *Store) delete(r *http.Request, kind string) {
+	key, err := s.key(r, kind)
+	if err == nil {
+		err = s.kv.Delete(r.Context(), key)
+	}
+	if err != nil {
+		s.opts.Logger.Warn("failed to delete secret",
+			log.String("kind", kind),
+			log.Error(err))
+	}
+}
+
+func (s *Store) SetState(r *http.Request, state string) error {
+	return s.set(r, "state", state)
+}
+
+func (s *Store) GetState(r *http.Request) (string, error) {
+	return s.get(r, "state")
+}
+
+func (s *Store) DeleteState(r *http.Request) {
+	s.delete(r, "state")
+}
+
+func (s *Store) SetNonce(r *http.Request, nonce string) error {
+	return s.set(r, "nonce", nonce)
+}
+
+func (s *Store) GetNonce(r *http.Request) (string, error) {
+	return s.get(r, "nonce")
+}
+
+func (s *Store) DeleteNonce(r *http.Request) {
+	s.delete(r, "nonce")
+}
+
+func (s *Store) SetCodeVerifier(r *http.Request, verifier string) error {
+	return s.set(r, "code_verifier", verifier)
+}
+
+func (s *Store) GetCodeVerifier(r *http.Request) (string, error) {
+	return s.get(r, "code_verifier")
+}
+
+func (s *Store) DeleteCodeVerifier(r *http.Request) {
+	s.delete(r, "code_verifier")
+}
+
+func (s *Store) SetReturnTo(r *http.Request, returnTo string) error {
+	return s.set(r, "return_to", returnTo)
+}
+
+func (s *Store) GetReturnTo(r *http.Request) (string, error) {
+	return s.get(r, "return_to")
+}
+
+func (s *Store) DeleteReturnTo(r *http.Request) {
+	s.delete(r, "return_to")
+}
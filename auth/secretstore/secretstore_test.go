@@ -0,0 +1,116 @@
+package secretstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth/secretstore/secretstoretest"
+)
+
+const testSessionCookie = "session"
+
+func sessionFromCookie(r *http.Request) (string, error) {
+	c, err := r.Cookie(testSessionCookie)
+	if err != nil {
+		return "", err
+	}
+	return c.Value, nil
+}
+
+func newRequest(sessionID string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: testSessionCookie, Value: sessionID})
+	return r
+}
+
+func TestMemoryConformance(t *testing.T) {
+	secretstoretest.Run(t, func(t *testing.T) (auth.SecretStore, func(string) *http.Request) {
+		store, err := NewMemory(Options{SessionID: sessionFromCookie})
+		require.NoError(t, err)
+		return store, newRequest
+	})
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Now()
+	kv := NewMemoryKV()
+	kv.now = func() time.Time { return now }
+	store, err := New(kv, Options{SessionID: sessionFromCookie, TTL: time.Minute})
+	require.NoError(t, err)
+
+	require.NoError(t, store.SetState(newRequest("alice"), "state-1"))
+	now = now.Add(30 * time.Second)
+	got, err := store.GetState(newRequest("alice"))
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", got)
+
+	now = now.Add(time.Minute)
+	got, err = store.GetState(newRequest("alice"))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// Expired entries are purged on writes, at most once per purge interval.
+	require.NoError(t, store.SetNonce(newRequest("alice"), "nonce-1"))
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, store.SetNonce(newRequest("bob"), "nonce-2"))
+	assert.Len(t, kv.entries, 1)
+
+	now = now.Add(50 * time.Second)
+	require.NoError(t, store.SetNonce(newRequest("carol"), "nonce-3"))
+	now = now.Add(15 * time.Second)
+	require.NoError(t, store.SetNonce(newRequest("dave"), "nonce-4"))
+	assert.Len(t, kv.entries, 2)
+
+	// Carol has expired, but is not purged within the purge interval.
+	now = now.Add(50 * time.Second)
+	require.NoError(t, store.SetNonce(newRequest("erin"), "nonce-5"))
+	assert.Len(t, kv.entries, 3)
+	got, err = store.GetNonce(newRequest("carol"))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestKeys(t *testing.T) {
+	kv := NewMemoryKV()
+	store, err := New(kv, Options{SessionID: sessionFromCookie, KeyPrefix: "myservice:"})
+	require.NoError(t, err)
+	require.NoError(t, store.SetState(newRequest("secret-session-id"), "state-1"))
+
+	for key := range kv.entries {
+		assert.Regexp(t, `^myservice:state:[0-9a-f]{64}$`, key)
+	}
+
+	// Requests without a session are rejected.
+	err = store.SetState(httptest.NewRequest(http.MethodGet, "/", nil), "state-1")
+	assert.ErrorContains(t, err, "get session ID")
+	_, err = kv.Get(context.Background(), "myservice:state:missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// failingDeleteKV is a MemoryKV that fails to delete keys.
+type failingDeleteKV struct {
+	*MemoryKV
+}
+
+func (failingDeleteKV) Delete(context.Context, string) error {
+	return errors.New("KV is unavailable")
+}
+
+func TestDeleteErrors(t *testing.T) {
+	logger, exportLogs := logtest.Captured(t)
+	store, err := New(failingDeleteKV{NewMemoryKV()}, Options{SessionID: sessionFromCookie, Logger: logger})
+	require.NoError(t, err)
+
+	store.DeleteState(newRequest("alice"))
+	store.DeleteNonce(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, []string{"failed to delete secret", "failed to delete secret"}, exportLogs().Messages())
+}
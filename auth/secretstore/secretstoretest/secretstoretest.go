@@ -0,0 +1,163 @@
+// Package secretstoretest provides a conformance test suite for implementations
+// of auth.SecretStore, including the optional auth.CodeVerifierStore and
+// auth.ReturnToStore.
+package secretstoretest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth"
+)
+
+// Factory returns a new, empty store under test, and a function that returns a
+// request belonging to the per-user session with the given ID.
+type Factory func(t *testing.T) (store auth.SecretStore, newRequest func(sessionID string) *http.Request)
+
+// secret is the set of accessors of one kind of secret.
+type secret struct {
+	name   string
+	set    func(s auth.SecretStore, r *http.Request, v string) error
+	get    func(s auth.SecretStore, r *http.Request) (string, error)
+	delete func(s auth.SecretStore, r *http.Request)
+	// supported returns true if the store implements the optional interface of
+	// the secret. It is nil for secrets of auth.SecretStore itself.
+	supported func(s auth.SecretStore) bool
+}
+
+var secrets = []secret{{
+	name:   "State",
+	set:    auth.SecretStore.SetState,
+	get:    auth.SecretStore.GetState,
+	delete: auth.SecretStore.DeleteState,
+}, {
+	name:   "Nonce",
+	set:    auth.SecretStore.SetNonce,
+	get:    auth.SecretStore.GetNonce,
+	delete: auth.SecretStore.DeleteNonce,
+}, {
+	name: "CodeVerifier",
+	set: func(s auth.SecretStore, r *http.Request, v string) error {
+		return s.(auth.CodeVerifierStore).SetCodeVerifier(r, v)
+	},
+	get: func(s auth.SecretStore, r *http.Request) (string, error) {
+		return s.(auth.CodeVerifierStore).GetCodeVerifier(r)
+	},
+	delete: func(s auth.SecretStore, r *http.Request) {
+		s.(auth.CodeVerifierStore).DeleteCodeVerifier(r)
+	},
+	supported: func(s auth.SecretStore) bool {
+		_, ok := s.(auth.CodeVerifierStore)
+		return ok
+	},
+}, {
+	name: "ReturnTo",
+	set: func(s auth.SecretStore, r *http.Request, v string) error {
+		return s.(auth.ReturnToStore).SetReturnTo(r, v)
+	},
+	get: func(s auth.SecretStore, r *http.Request) (string, error) {
+		return s.(auth.ReturnToStore).GetReturnTo(r)
+	},
+	delete: func(s auth.SecretStore, r *http.Request) {
+		s.(auth.ReturnToStore).DeleteReturnTo(r)
+	},
+	supported: func(s auth.SecretStore) bool {
+		_, ok := s.(auth.ReturnToStore)
+		return ok
+	},
+}}
+
+// supportedSecrets returns the secrets that are supported by the store.
+func supportedSecrets(store auth.SecretStore) []secret {
+	var supported []secret
+	for _, sec := range secrets {
+		if sec.supported == nil || sec.supported(store) {
+			supported = append(supported, sec)
+		}
+	}
+	return supported
+}
+
+// Run runs the conformance test suite against stores created by the factory.
+// Stores MUST return an empty string without an error for secrets that are not
+// set. Secrets of optional interfaces are only tested if the store implements
+// them.
+func Run(t *testing.T, factory Factory) {
+	store, _ := factory(t)
+	for _, sec := range supportedSecrets(store) {
+		t.Run(sec.name, func(t *testing.T) {
+			t.Run("not set", func(t *testing.T) {
+				store, newRequest := factory(t)
+				got, err := sec.get(store, newRequest("alice"))
+				require.NoError(t, err)
+				assert.Empty(t, got)
+			})
+
+			t.Run("round trip", func(t *testing.T) {
+				store, newRequest := factory(t)
+				require.NoError(t, sec.set(store, newRequest("alice"), "secret-1"))
+				got, err := sec.get(store, newRequest("alice"))
+				require.NoError(t, err)
+				assert.Equal(t, "secret-1", got)
+			})
+
+			t.Run("overwrite", func(t *testing.T) {
+				store, newRequest := factory(t)
+				require.NoError(t, sec.set(store, newRequest("alice"), "secret-1"))
+				require.NoError(t, sec.set(store, newRequest("alice"), "secret-2"))
+				got, err := sec.get(store, newRequest("alice"))
+				require.NoError(t, err)
+				assert.Equal(t, "secret-2", got)
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				store, newRequest := factory(t)
+				require.NoError(t, sec.set(store, newRequest("alice"), "secret-1"))
+				sec.delete(store, newRequest("alice"))
+				got, err := sec.get(store, newRequest("alice"))
+				require.NoError(t, err)
+				assert.Empty(t, got)
+
+				// Deleting again is a no-op.
+				sec.delete(store, newRequest("alice"))
+			})
+
+			t.Run("isolated between sessions", func(t *testing.T) {
+				store, newRequest := factory(t)
+				require.NoError(t, sec.set(store, newRequest("alice"), "secret-alice"))
+				got, err := sec.get(store, newRequest("bob"))
+				require.NoError(t, err)
+				assert.Empty(t, got)
+
+				require.NoError(t, sec.set(store, newRequest("bob"), "secret-bob"))
+				sec.delete(store, newRequest("bob"))
+				got, err = sec.get(store, newRequest("alice"))
+				require.NoError(t, err)
+				assert.Equal(t, "secret-alice", got)
+			})
+		})
+	}
+
+	t.Run("isolated between secrets", func(t *testing.T) {
+		store, newRequest := factory(t)
+		secrets := supportedSecrets(store)
+		for _, sec := range secrets {
+			require.NoError(t, sec.set(store, newRequest("alice"), "secret-"+sec.name))
+		}
+		for _, sec := range secrets {
+			got, err := sec.get(store, newRequest("alice"))
+			require.NoError(t, err)
+			assert.Equal(t, "secret-"+sec.name, got, sec.name)
+		}
+
+		secrets[0].delete(store, newRequest("alice"))
+		for _, sec := range secrets[1:] {
+			got, err := sec.get(store, newRequest("alice"))
+			require.NoError(t, err)
+			assert.Equal(t, "secret-"+sec.name, got, sec.name)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package sessions
+
+import (
+	"context"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+)
+
+type contextKey int
+
+const (
+	sessionKey contextKey = iota
+)
+
+// SessionFromContext returns the authenticated SAMS session from the given
+// context, or nil if the request was not authenticated. This is generally set
+// by sessions.HTTPAuthenticator.
+func SessionFromContext(ctx context.Context) *clientsv1.Session {
+	session, _ := ctx.Value(sessionKey).(*clientsv1.Session)
+	return session
+}
+
+// UserFromContext returns the SAMS user of the authenticated session from the
+// given context, or nil if the request was not authenticated.
+func UserFromContext(ctx context.Context) *clientsv1.User {
+	return SessionFromContext(ctx).GetUser()
+}
+
+// WithSession returns a new context with the given session.
+func WithSession(ctx context.Context, session *clientsv1.Session) context.Context {
+	return context.WithValue(ctx, sessionKey, session)
+}
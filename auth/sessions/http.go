@@ -0,0 +1,160 @@
+package sessions
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth"
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/internal/connectutil"
+)
+
+// HTTPAuthenticatorOptions configures sessions.NewHTTPAuthenticator.
+type HTTPAuthenticatorOptions struct {
+	// CookieName is the name of the cookie that carries the SAMS session ID.
+	CookieName string
+	// HeaderName is the name of the header that carries the SAMS session ID. It
+	// is only consulted when the cookie is absent or empty.
+	HeaderName string
+	// UnauthenticatedHandler is the HTTP handler to call when RequireSession
+	// rejects a request. Use auth.ErrorFromContext to extract the error.
+	//
+	// The default responds with a plain 401 Unauthorized.
+	UnauthenticatedHandler http.Handler
+}
+
+func (opts HTTPAuthenticatorOptions) Validate() error {
+	if opts.CookieName == "" && opts.HeaderName == "" {
+		return errors.New("at least one of CookieName or HeaderName is required")
+	}
+	return nil
+}
+
+// See sessions.NewHTTPAuthenticator.
+type HTTPAuthenticator struct {
+	logger   log.Logger
+	sessions SessionGetter
+	opts     HTTPAuthenticatorOptions
+}
+
+// NewHTTPAuthenticator provides a factory for auth middleware that resolves
+// the SAMS session ID carried by incoming HTTP requests through the SAMS
+// Sessions API, and makes the authenticated session and user available via
+// sessions.SessionFromContext and sessions.UserFromContext.
+//
+// The provided logger is used to record internal-server errors.
+func NewHTTPAuthenticator(logger log.Logger, sessions SessionGetter, opts HTTPAuthenticatorOptions) (*HTTPAuthenticator, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.UnauthenticatedHandler == nil {
+		opts.UnauthenticatedHandler = DefaultUnauthenticatedHandler
+	}
+	return &HTTPAuthenticator{
+		logger:   logger.Scoped("sessions"),
+		sessions: sessions,
+		opts:     opts,
+	}, nil
+}
+
+// RequireSession only calls next if the incoming HTTP request carries the ID
+// of a SAMS session that is authenticated by a user. Otherwise, the configured
+// UnauthenticatedHandler is called instead.
+func (a *HTTPAuthenticator) RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := connectutil.LoggerWithTrace(ctx, a.logger)
+
+		session, err := a.resolveSession(r)
+		if err != nil {
+			if isUnauthenticated(err) {
+				logger.Debug("rejecting unauthenticated request", log.Error(err))
+				ctx = auth.WithError(ctx, &auth.Error{
+					StatusCode: http.StatusUnauthorized,
+					Cause:      err,
+				})
+				a.opts.UnauthenticatedHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			code := http.StatusInternalServerError
+			if errors.Is(err, context.Canceled) {
+				code = http.StatusBadRequest
+				logger.Warn("error resolving session", log.Error(err))
+			} else {
+				logger.Error("error resolving session", log.Error(err))
+			}
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithSession(ctx, session)))
+	})
+}
+
+// OptionalSession always calls next, with the SAMS session and user available
+// in the request context if the incoming HTTP request carries the ID of a SAMS
+// session that is authenticated by a user.
+//
+// Errors from SAMS are logged, and the request proceeds as unauthenticated.
+func (a *HTTPAuthenticator) OptionalSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		session, err := a.resolveSession(r)
+		if err != nil {
+			if !isUnauthenticated(err) {
+				connectutil.LoggerWithTrace(ctx, a.logger).Error("error resolving session", log.Error(err))
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithSession(ctx, session)))
+	})
+}
+
+var (
+	errSessionNotFound         = errors.New("session not found")
+	errSessionNotAuthenticated = errors.New("session is not authenticated by a user")
+)
+
+// isUnauthenticated returns true if the request does not carry a session that
+// is authenticated by a user, as opposed to failing to resolve the session.
+func isUnauthenticated(err error) bool {
+	return errors.Is(err, errNoSessionID) ||
+		errors.Is(err, errSessionNotFound) ||
+		errors.Is(err, errSessionNotAuthenticated)
+}
+
+func (a *HTTPAuthenticator) resolveSession(r *http.Request) (*clientsv1.Session, error) {
+	id, err := extractSessionID(r, a.opts.CookieName, a.opts.HeaderName)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := a.sessions.GetSessionByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sams.ErrNotFound) {
+			return nil, errSessionNotFound
+		}
+		return nil, errors.Wrap(err, "get session")
+	}
+	if session.GetUser() == nil {
+		return nil, errSessionNotAuthenticated
+	}
+	return session, nil
+}
+
+// DefaultUnauthenticatedHandler responds with the status code extracted from
+// the context, without revealing the underlying error.
+var DefaultUnauthenticatedHandler = http.HandlerFunc(unauthenticatedHandler)
+
+func unauthenticatedHandler(w http.ResponseWriter, r *http.Request) {
+	code := auth.ErrorFromContext(r.Context()).StatusCode
+	http.Error(w, http.StatusText(code), code)
+}
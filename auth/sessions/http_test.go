@@ -0,0 +1,152 @@
+package sessions
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth"
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+)
+
+func TestHTTPAuthenticator(t *testing.T) {
+	getter := &mockSessionGetter{
+		sessions: map[string]*clientsv1.Session{
+			"authenticated": {
+				Id:   "authenticated",
+				User: &clientsv1.User{Id: "user-1", Name: "Alice"},
+			},
+			"anonymous": {
+				Id: "anonymous",
+			},
+		},
+	}
+
+	// echoUser responds with the name of the user in context, if any.
+	echoUser := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := UserFromContext(r.Context())
+		if user == nil {
+			_, _ = w.Write([]byte("anonymous"))
+			return
+		}
+		_, _ = w.Write([]byte(user.GetName()))
+	})
+
+	for _, tc := range []struct {
+		name    string
+		getter  SessionGetter
+		request func(r *http.Request)
+
+		wantRequired autogold.Value
+		wantOptional autogold.Value
+		wantLogs     autogold.Value
+	}{{
+		name:         "no session ID",
+		getter:       getter,
+		request:      func(*http.Request) {},
+		wantRequired: autogold.Expect("401 Unauthorized\n"),
+		wantOptional: autogold.Expect("200 anonymous"),
+		wantLogs:     autogold.Expect([]string{"rejecting unauthenticated request"}),
+	}, {
+		name:   "authenticated session from cookie",
+		getter: getter,
+		request: func(r *http.Request) {
+			r.AddCookie(&http.Cookie{Name: "sams_session", Value: "authenticated"})
+		},
+		wantRequired: autogold.Expect("200 Alice"),
+		wantOptional: autogold.Expect("200 Alice"),
+		wantLogs:     autogold.Expect([]string{}),
+	}, {
+		name:   "authenticated session from header",
+		getter: getter,
+		request: func(r *http.Request) {
+			r.Header.Set("X-Sams-Session", "authenticated")
+		},
+		wantRequired: autogold.Expect("200 Alice"),
+		wantOptional: autogold.Expect("200 Alice"),
+		wantLogs:     autogold.Expect([]string{}),
+	}, {
+		name:   "session not found",
+		getter: getter,
+		request: func(r *http.Request) {
+			r.AddCookie(&http.Cookie{Name: "sams_session", Value: "not-a-session"})
+		},
+		wantRequired: autogold.Expect("401 Unauthorized\n"),
+		wantOptional: autogold.Expect("200 anonymous"),
+		wantLogs:     autogold.Expect([]string{"rejecting unauthenticated request"}),
+	}, {
+		name:   "session not authenticated by a user",
+		getter: getter,
+		request: func(r *http.Request) {
+			r.AddCookie(&http.Cookie{Name: "sams_session", Value: "anonymous"})
+		},
+		wantRequired: autogold.Expect("401 Unauthorized\n"),
+		wantOptional: autogold.Expect("200 anonymous"),
+		wantLogs:     autogold.Expect([]string{"rejecting unauthenticated request"}),
+	}, {
+		name:   "SAMS error",
+		getter: &mockSessionGetter{err: errors.New("oh no")},
+		request: func(r *http.Request) {
+			r.AddCookie(&http.Cookie{Name: "sams_session", Value: "authenticated"})
+		},
+		wantRequired: autogold.Expect("500 Internal Server Error\n"),
+		wantOptional: autogold.Expect("200 anonymous"),
+		wantLogs:     autogold.Expect([]string{"error resolving session", "error resolving session"}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, exportLogs := logtest.Captured(t)
+			authenticator, err := NewHTTPAuthenticator(logger, tc.getter, HTTPAuthenticatorOptions{
+				CookieName: "sams_session",
+				HeaderName: "X-Sams-Session",
+			})
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.Handle("/required", authenticator.RequireSession(echoUser))
+			mux.Handle("/optional", authenticator.OptionalSession(echoUser))
+			srv := httptest.NewServer(mux)
+			t.Cleanup(srv.Close)
+
+			do := func(path string) string {
+				req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+				require.NoError(t, err)
+				tc.request(req)
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				return resp.Status[:3] + " " + string(body)
+			}
+			tc.wantRequired.Equal(t, do("/required"))
+			tc.wantOptional.Equal(t, do("/optional"))
+			tc.wantLogs.Equal(t, exportLogs().Messages())
+		})
+	}
+
+	t.Run("custom unauthenticated handler", func(t *testing.T) {
+		authenticator, err := NewHTTPAuthenticator(logtest.Scoped(t), getter, HTTPAuthenticatorOptions{
+			CookieName: "sams_session",
+			UnauthenticatedHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/login?reason="+auth.ErrorFromContext(r.Context()).Cause.Error(), http.StatusFound)
+			}),
+		})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		authenticator.RequireSession(echoUser).
+			ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		autogold.Expect("/login?reason=no session ID provided").Equal(t, w.Header().Get("Location"))
+	})
+
+	t.Run("missing session ID sources", func(t *testing.T) {
+		_, err := NewHTTPAuthenticator(logtest.Scoped(t), getter, HTTPAuthenticatorOptions{})
+		require.Error(t, err)
+	})
+}
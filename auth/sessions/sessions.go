@@ -0,0 +1,38 @@
+package sessions
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+)
+
+type SessionGetter interface {
+	// GetSessionByID returns the SAMS session with the given ID, or
+	// sams.ErrNotFound if no such session exists. This is generally implemented by
+	// *sams.SessionsServiceV1, which caches sessions when
+	// ClientV1Config.SessionsCacheSize is set.
+	GetSessionByID(ctx context.Context, id string) (*clientsv1.Session, error)
+}
+
+// errNoSessionID is returned when the request does not carry a session ID.
+var errNoSessionID = errors.New("no session ID provided")
+
+// extractSessionID returns the session ID from the named cookie, falling back
+// to the named header. Either name may be empty to skip that source.
+func extractSessionID(r *http.Request, cookieName, headerName string) (string, error) {
+	if cookieName != "" {
+		if c, err := r.Cookie(cookieName); err == nil && c.Value != "" {
+			return c.Value, nil
+		}
+	}
+	if headerName != "" {
+		if v := strings.TrimSpace(r.Header.Get(headerName)); v != "" {
+			return v, nil
+		}
+	}
+	return "", errNoSessionID
+}
@@ -0,0 +1,24 @@
+package sessions
+
+import (
+	"context"
+
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+)
+
+type mockSessionGetter struct {
+	sessions map[string]*clientsv1.Session
+	err      error
+}
+
+func (m *mockSessionGetter) GetSessionByID(_ context.Context, id string) (*clientsv1.Session, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, sams.ErrNotFound
+	}
+	return session, nil
+}
@@ -0,0 +1,241 @@
+package tokenstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"golang.org/x/oauth2"
+)
+
+// KV is the interface of a generic key-value storage backend, e.g. Redis or a
+// database table, for EncryptedStore.
+type KV interface {
+	// Get returns the value of the given key. It returns ErrNotFound if the key
+	// does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set sets the value of the given key. Implementations MUST replace any
+	// existing value atomically, i.e. a concurrent Get observes either the old or
+	// the new value in full.
+	Set(ctx context.Context, key string, value []byte) error
+	// CompareAndSwap sets the value of the given key only if its current value is
+	// exactly old, and returns ErrConflict otherwise, including when the key does
+	// not exist. Implementations MUST compare and set atomically across all
+	// replicas sharing the backend, e.g. with a Redis transaction or a
+	// conditional database update.
+	CompareAndSwap(ctx context.Context, key string, old, value []byte) error
+	// Delete deletes the given key. It does not return an error if the key does
+	// not exist.
+	Delete(ctx context.Context, key string) error
+}
+
+// EncryptedStore is a TokenStore that encrypts tokens at rest with AES-GCM
+// before writing them to the underlying KV.
+type EncryptedStore struct {
+	kv   KV
+	aead cipher.AEAD
+}
+
+var _ TokenStore = (*EncryptedStore)(nil)
+
+// NewEncryptedStore returns a token store that persists tokens to the given KV,
+// encrypted with the given 32-byte key (AES-256-GCM). The key MUST be kept
+// secret and stable, tokens encrypted with a different key cannot be read.
+func NewEncryptedStore(kv KV, key []byte) (*EncryptedStore, error) {
+	if len(key) != 32 {
+		return nil, errors.Newf("key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "create GCM")
+	}
+	return &EncryptedStore{kv: kv, aead: aead}, nil
+}
+
+func kvKey(userID string) string {
+	return "sams_user_token:" + userID
+}
+
+func (s *EncryptedStore) Get(ctx context.Context, userID string) (*oauth2.Token, error) {
+	ciphertext, err := s.kv.Get(ctx, kvKey(userID))
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(userID, ciphertext)
+}
+
+func (s *EncryptedStore) decrypt(userID string, ciphertext []byte) (*oauth2.Token, error) {
+	nonceSize := s.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("malformed ciphertext")
+	}
+	// The user ID is used as additional data so that a ciphertext cannot be
+	// swapped to another user.
+	plaintext, err := s.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], []byte(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt token")
+	}
+	var token oauth2.Token
+	if err = json.Unmarshal(plaintext, &token); err != nil {
+		return nil, errors.Wrap(err, "unmarshal token")
+	}
+	return &token, nil
+}
+
+func (s *EncryptedStore) encrypt(userID string, token *oauth2.Token) ([]byte, error) {
+	if token == nil {
+		return nil, errors.New("token cannot be nil")
+	}
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal token")
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "generate nonce")
+	}
+	return s.aead.Seal(nonce, nonce, plaintext, []byte(userID)), nil
+}
+
+func (s *EncryptedStore) Put(ctx context.Context, userID string, token *oauth2.Token) error {
+	ciphertext, err := s.encrypt(userID, token)
+	if err != nil {
+		return err
+	}
+	return s.kv.Set(ctx, kvKey(userID), ciphertext)
+}
+
+// CompareAndSwap decrypts the stored token to compare its refresh token, and
+// then swaps the exact ciphertext that was read, so that a concurrent write in
+// between is detected by the KV.
+func (s *EncryptedStore) CompareAndSwap(ctx context.Context, userID, oldRefreshToken string, token *oauth2.Token) error {
+	old, err := s.kv.Get(ctx, kvKey(userID))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrConflict
+		}
+		return err
+	}
+	current, err := s.decrypt(userID, old)
+	if err != nil {
+		return err
+	}
+	if current.RefreshToken != oldRefreshToken {
+		return ErrConflict
+	}
+	ciphertext, err := s.encrypt(userID, token)
+	if err != nil {
+		return err
+	}
+	return s.kv.CompareAndSwap(ctx, kvKey(userID), old, ciphertext)
+}
+
+func (s *EncryptedStore) Delete(ctx context.Context, userID string) error {
+	return s.kv.Delete(ctx, kvKey(userID))
+}
+
+// FileKV is a KV that stores each key as a file in a directory, which is
+// suitable for CLIs and single-replica services with persistent disks. Writes
+// are only atomic within the same process.
+type FileKV struct {
+	dir string
+	// mu serializes writes, so that CompareAndSwap is atomic.
+	mu sync.Mutex
+}
+
+var _ KV = (*FileKV)(nil)
+
+// NewFileKV returns a KV that stores values in the given directory, creating
+// it if it does not exist.
+func NewFileKV(dir string) (*FileKV, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "create directory")
+	}
+	return &FileKV{dir: dir}, nil
+}
+
+// path returns the file path of the key. Keys are hashed to avoid any path
+// traversal and to not leak keys through file names.
+func (kv *FileKV) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(kv.dir, hex.EncodeToString(sum[:]))
+}
+
+func (kv *FileKV) Get(_ context.Context, key string) ([]byte, error) {
+	value, err := os.ReadFile(kv.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set writes the value to a temporary file and renames it over the existing
+// file, so the value is replaced atomically.
+func (kv *FileKV) Set(_ context.Context, key string, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	return kv.set(key, value)
+}
+
+func (kv *FileKV) CompareAndSwap(ctx context.Context, key string, old, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	current, err := kv.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrConflict
+		}
+		return err
+	}
+	if !bytes.Equal(current, old) {
+		return ErrConflict
+	}
+	return kv.set(key, value)
+}
+
+func (kv *FileKV) set(key string, value []byte) error {
+	f, err := os.CreateTemp(kv.dir, ".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "create temporary file")
+	}
+	defer func() { _ = os.Remove(f.Name()) }() // no-op once renamed
+
+	if _, err = f.Write(value); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "write temporary file")
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "sync temporary file")
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrap(err, "close temporary file")
+	}
+	return os.Rename(f.Name(), kv.path(key))
+}
+
+func (kv *FileKV) Delete(_ context.Context, key string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	err := os.Remove(kv.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
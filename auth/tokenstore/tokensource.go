@@ -0,0 +1,134 @@
+package tokenstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"golang.org/x/oauth2"
+)
+
+// NewTokenSource returns an oauth2.TokenSource for the user with the given ID
+// that is backed by the token store. The stored token is refreshed using the
+// given OAuth2 configuration (the same used for the login flow) when it
+// expires, and the refreshed token is persisted before being returned, so that
+// a rotated refresh token is never lost.
+//
+// Token calls are serialized per TokenSource, so callers should reuse the same
+// TokenSource for a user within the process. Across processes, e.g. replicas
+// sharing the store, the refreshed token is only persisted with
+// TokenStore.CompareAndSwap if the stored token has not been refreshed
+// elsewhere in the meantime. Otherwise, the token refreshed elsewhere is
+// re-read from the store and used instead.
+func NewTokenSource(ctx context.Context, config *oauth2.Config, store TokenStore, userID string) oauth2.TokenSource {
+	return &tokenSource{
+		ctx:    ctx,
+		config: config,
+		store:  store,
+		userID: userID,
+	}
+}
+
+type tokenSource struct {
+	ctx    context.Context
+	config *oauth2.Config
+	store  TokenStore
+	userID string
+
+	// mu protects the fields below.
+	mu sync.Mutex
+	// token is the last known token.
+	token *oauth2.Token
+	// unsaved is true if token failed to be persisted to the store.
+	unsaved bool
+	// refreshedFrom is the refresh token that token was refreshed from, which is
+	// expected to be stored when persisting token.
+	refreshedFrom string
+}
+
+func (s *tokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		if s.unsaved {
+			// The failure to persist has been reported when the token was refreshed,
+			// keep retrying on subsequent calls while the token is still usable.
+			token, err := s.save(s.token)
+			if err == nil {
+				return token, nil
+			}
+			if s.token == nil {
+				// The stored token has been deleted in the meantime.
+				return nil, err
+			}
+		}
+		return s.token, nil
+	}
+
+	current := s.token
+	if !s.unsaved {
+		// Start from the stored token unless ours is newer, it may have been
+		// refreshed elsewhere.
+		stored, err := s.store.Get(s.ctx, s.userID)
+		if err != nil {
+			return nil, errors.Wrap(err, "get stored token")
+		}
+		if stored.Valid() {
+			s.token = stored
+			return stored, nil
+		}
+		current = stored
+	}
+	if current.RefreshToken == "" {
+		return nil, errors.New("token expired and no refresh token is available")
+	}
+
+	refreshed, err := s.config.TokenSource(s.ctx, current).Token()
+	if err != nil {
+		// Another replica may have refreshed with the same refresh token first,
+		// which revokes it by rotation, so pick up the token it has persisted.
+		stored, getErr := s.store.Get(s.ctx, s.userID)
+		if getErr == nil && stored.Valid() && stored.RefreshToken != current.RefreshToken {
+			s.token = stored
+			s.unsaved = false
+			return stored, nil
+		}
+		return nil, errors.Wrap(err, "refresh token")
+	}
+	// Hold onto the refreshed token before persisting, the previous refresh token
+	// may have been revoked by rotation and we must not lose the new one.
+	s.token = refreshed
+	if !s.unsaved {
+		// Otherwise, the store still has the refresh token our unsaved token was
+		// refreshed from.
+		s.refreshedFrom = current.RefreshToken
+	}
+	return s.save(refreshed)
+}
+
+// save persists the token if the stored token still has the refresh token it
+// was refreshed from. If the stored token has been changed elsewhere in the
+// meantime, the stored token is adopted and returned instead.
+func (s *tokenSource) save(token *oauth2.Token) (*oauth2.Token, error) {
+	err := s.store.CompareAndSwap(s.ctx, s.userID, s.refreshedFrom, token)
+	if err == nil {
+		s.unsaved = false
+		return token, nil
+	}
+	if !errors.Is(err, ErrConflict) {
+		s.unsaved = true
+		return nil, errors.Wrap(err, "persist token")
+	}
+
+	s.unsaved = false
+	stored, err := s.store.Get(s.ctx, s.userID)
+	if err != nil {
+		// The token may have been deleted, e.g. upon logout, which must not be
+		// undone by us.
+		s.token = nil
+		return nil, errors.Wrap(err, "get stored token after conflict")
+	}
+	s.token = stored
+	return stored, nil
+}
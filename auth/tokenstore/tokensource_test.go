@@ -0,0 +1,216 @@
+package tokenstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// newRotatingTokenServer returns a fake SAMS token endpoint that rotates the
+// refresh token upon every refresh, and rejects any refresh token other than
+// the latest one.
+func newRotatingTokenServer(t *testing.T) (*httptest.Server, *int) {
+	refreshes := 0
+	latest := "sams_rt_0"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("refresh_token") != latest {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		refreshes++
+		latest = fmt.Sprintf("sams_rt_%d", refreshes)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  fmt.Sprintf("sams_at_%d", refreshes),
+			"token_type":    "Bearer",
+			"refresh_token": latest,
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &refreshes
+}
+
+type flakyStore struct {
+	TokenStore
+	putErr error
+	// stale, if set, is returned by the next Get instead of the stored token, as
+	// if it was read before another replica refreshed it.
+	stale *oauth2.Token
+	// beforeSwap, if set, is called before the next CompareAndSwap, as if
+	// another replica wrote to the store concurrently.
+	beforeSwap func()
+}
+
+func (s *flakyStore) Get(ctx context.Context, userID string) (*oauth2.Token, error) {
+	if stale := s.stale; stale != nil {
+		s.stale = nil
+		return stale, nil
+	}
+	return s.TokenStore.Get(ctx, userID)
+}
+
+func (s *flakyStore) CompareAndSwap(ctx context.Context, userID, oldRefreshToken string, token *oauth2.Token) error {
+	if s.putErr != nil {
+		return s.putErr
+	}
+	if beforeSwap := s.beforeSwap; beforeSwap != nil {
+		s.beforeSwap = nil
+		beforeSwap()
+	}
+	return s.TokenStore.CompareAndSwap(ctx, userID, oldRefreshToken, token)
+}
+
+func TestTokenSource(t *testing.T) {
+	ctx := context.Background()
+	expired := &oauth2.Token{
+		AccessToken:  "sams_at_expired",
+		RefreshToken: "sams_rt_0",
+		Expiry:       time.Now().Add(-time.Minute),
+	}
+
+	t.Run("refreshes and persists rotated refresh token", func(t *testing.T) {
+		srv, refreshes := newRotatingTokenServer(t)
+		config := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+		store := NewMemoryStore()
+		require.NoError(t, store.Put(ctx, "alice", expired))
+
+		ts := NewTokenSource(ctx, config, store, "alice")
+		for range 3 {
+			token, err := ts.Token()
+			require.NoError(t, err)
+			assert.Equal(t, "sams_at_1", token.AccessToken)
+		}
+		assert.Equal(t, 1, *refreshes)
+
+		stored, err := store.Get(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, "sams_rt_1", stored.RefreshToken)
+
+		// A new token source, e.g. after a restart, picks up the persisted token.
+		token, err := NewTokenSource(ctx, config, store, "alice").Token()
+		require.NoError(t, err)
+		assert.Equal(t, "sams_at_1", token.AccessToken)
+		assert.Equal(t, 1, *refreshes)
+	})
+
+	t.Run("keeps rotated refresh token when persisting fails", func(t *testing.T) {
+		srv, refreshes := newRotatingTokenServer(t)
+		config := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+		store := &flakyStore{TokenStore: NewMemoryStore()}
+		require.NoError(t, store.Put(ctx, "alice", expired))
+
+		ts := NewTokenSource(ctx, config, store, "alice")
+		store.putErr = errors.New("store unavailable")
+		_, err := ts.Token()
+		require.Error(t, err)
+		assert.Equal(t, 1, *refreshes)
+
+		// Once the store recovers, the rotated token is persisted.
+		store.putErr = nil
+		token, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "sams_at_1", token.AccessToken)
+		stored, err := store.Get(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, "sams_rt_1", stored.RefreshToken)
+	})
+
+	t.Run("adopts token persisted by another replica", func(t *testing.T) {
+		srv, refreshes := newRotatingTokenServer(t)
+		config := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+		store := &flakyStore{TokenStore: NewMemoryStore()}
+		require.NoError(t, store.Put(ctx, "alice", expired))
+
+		other := &oauth2.Token{
+			AccessToken:  "sams_at_other",
+			RefreshToken: "sams_rt_other",
+			Expiry:       time.Now().Add(time.Hour),
+		}
+		store.beforeSwap = func() {
+			require.NoError(t, store.Put(ctx, "alice", other))
+		}
+		token, err := NewTokenSource(ctx, config, store, "alice").Token()
+		require.NoError(t, err)
+		assert.Equal(t, "sams_at_other", token.AccessToken)
+		assert.Equal(t, 1, *refreshes)
+
+		stored, err := store.Get(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, "sams_rt_other", stored.RefreshToken)
+	})
+
+	t.Run("re-reads store when refresh token was rotated by another replica", func(t *testing.T) {
+		srv, refreshes := newRotatingTokenServer(t)
+		config := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+		store := &flakyStore{TokenStore: NewMemoryStore()}
+		require.NoError(t, store.Put(ctx, "alice", expired))
+
+		// The first replica refreshes and persists the rotated token.
+		token, err := NewTokenSource(ctx, config, store, "alice").Token()
+		require.NoError(t, err)
+		assert.Equal(t, "sams_at_1", token.AccessToken)
+
+		// The second replica read the token before it was rotated.
+		store.stale = expired
+		token, err = NewTokenSource(ctx, config, store, "alice").Token()
+		require.NoError(t, err)
+		assert.Equal(t, "sams_at_1", token.AccessToken)
+		assert.Equal(t, 1, *refreshes)
+	})
+
+	t.Run("does not resurrect deleted token", func(t *testing.T) {
+		srv, _ := newRotatingTokenServer(t)
+		config := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+		store := &flakyStore{TokenStore: NewMemoryStore()}
+		require.NoError(t, store.Put(ctx, "alice", expired))
+
+		store.beforeSwap = func() {
+			require.NoError(t, store.Delete(ctx, "alice"))
+		}
+		_, err := NewTokenSource(ctx, config, store, "alice").Token()
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = store.Get(ctx, "alice")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("does not return deleted token after persisting failed", func(t *testing.T) {
+		srv, _ := newRotatingTokenServer(t)
+		config := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+		store := &flakyStore{TokenStore: NewMemoryStore()}
+		require.NoError(t, store.Put(ctx, "alice", expired))
+
+		ts := NewTokenSource(ctx, config, store, "alice")
+		store.putErr = errors.New("store unavailable")
+		_, err := ts.Token()
+		require.Error(t, err)
+
+		// The token is deleted, e.g. upon logout, before the store recovers.
+		store.putErr = nil
+		require.NoError(t, store.Delete(ctx, "alice"))
+		for range 2 {
+			token, err := ts.Token()
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.Nil(t, token)
+		}
+		_, err = store.Get(ctx, "alice")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("no stored token", func(t *testing.T) {
+		_, err := NewTokenSource(ctx, &oauth2.Config{}, NewMemoryStore(), "alice").Token()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
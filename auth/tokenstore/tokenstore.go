@@ -0,0 +1,92 @@
+package tokenstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"golang.org/x/oauth2"
+)
+
+var (
+	// ErrNotFound is returned when no token is stored for a user.
+	ErrNotFound = errors.New("token not found")
+	// ErrConflict is returned by CompareAndSwap when the stored token has been
+	// changed or deleted elsewhere, e.g. refreshed by another replica.
+	ErrConflict = errors.New("token conflict")
+)
+
+// TokenStore is the interface for persisting SAMS user tokens, most notably
+// the refresh token that is issued when the "offline_access" scope is
+// requested (see auth.UserInfo.Token).
+type TokenStore interface {
+	// Get returns the token of the user with the given ID. It returns ErrNotFound
+	// if no token is stored for the user.
+	Get(ctx context.Context, userID string) (*oauth2.Token, error)
+	// Put stores the token of the user with the given ID, replacing any existing
+	// token.
+	Put(ctx context.Context, userID string, token *oauth2.Token) error
+	// CompareAndSwap stores the token of the user with the given ID only if the
+	// stored token still has the given refresh token, and returns ErrConflict
+	// otherwise, including when no token is stored for the user. Implementations
+	// MUST compare and write atomically across all replicas sharing the store.
+	CompareAndSwap(ctx context.Context, userID, oldRefreshToken string, token *oauth2.Token) error
+	// Delete deletes the token of the user with the given ID. It does not return
+	// an error if no token is stored for the user.
+	Delete(ctx context.Context, userID string) error
+}
+
+// MemoryStore is a TokenStore that keeps tokens in memory, which is only
+// suitable for tests and short-lived processes as tokens are lost on restart.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	tokens map[string]oauth2.Token
+}
+
+var _ TokenStore = (*MemoryStore)(nil)
+
+// NewMemoryStore returns a new, empty in-memory token store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: make(map[string]oauth2.Token)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, userID string) (*oauth2.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	token, ok := s.tokens[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &token, nil
+}
+
+func (s *MemoryStore) Put(_ context.Context, userID string, token *oauth2.Token) error {
+	if token == nil {
+		return errors.New("token cannot be nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[userID] = *token
+	return nil
+}
+
+func (s *MemoryStore) CompareAndSwap(_ context.Context, userID, oldRefreshToken string, token *oauth2.Token) error {
+	if token == nil {
+		return errors.New("token cannot be nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.tokens[userID]
+	if !ok || current.RefreshToken != oldRefreshToken {
+		return ErrConflict
+	}
+	s.tokens[userID] = *token
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, userID)
+	return nil
+}
@@ -0,0 +1,116 @@
+package tokenstore
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestStores(t *testing.T) {
+	key := make([]byte, 32)
+	for name, newStore := range map[string]func(t *testing.T) TokenStore{
+		"memory": func(*testing.T) TokenStore {
+			return NewMemoryStore()
+		},
+		"encrypted file": func(t *testing.T) TokenStore {
+			kv, err := NewFileKV(t.TempDir())
+			require.NoError(t, err)
+			store, err := NewEncryptedStore(kv, key)
+			require.NoError(t, err)
+			return store
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t)
+
+			_, err := store.Get(ctx, "alice")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			token := &oauth2.Token{
+				AccessToken:  "sams_at_alice",
+				TokenType:    "Bearer",
+				RefreshToken: "sams_rt_alice",
+				Expiry:       time.Now().Add(time.Hour).Round(time.Second),
+			}
+			require.NoError(t, store.Put(ctx, "alice", token))
+			got, err := store.Get(ctx, "alice")
+			require.NoError(t, err)
+			assert.Equal(t, token.AccessToken, got.AccessToken)
+			assert.Equal(t, token.RefreshToken, got.RefreshToken)
+			assert.True(t, token.Expiry.Equal(got.Expiry))
+
+			_, err = store.Get(ctx, "bob")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			rotated := &oauth2.Token{AccessToken: "sams_at_rotated", RefreshToken: "sams_rt_rotated"}
+			assert.ErrorIs(t, store.CompareAndSwap(ctx, "alice", "sams_rt_stale", rotated), ErrConflict)
+			assert.ErrorIs(t, store.CompareAndSwap(ctx, "bob", "", rotated), ErrConflict)
+			require.NoError(t, store.CompareAndSwap(ctx, "alice", "sams_rt_alice", rotated))
+			got, err = store.Get(ctx, "alice")
+			require.NoError(t, err)
+			assert.Equal(t, "sams_rt_rotated", got.RefreshToken)
+
+			require.NoError(t, store.Delete(ctx, "alice"))
+			_, err = store.Get(ctx, "alice")
+			assert.ErrorIs(t, err, ErrNotFound)
+			// Deleting again is not an error.
+			require.NoError(t, store.Delete(ctx, "alice"))
+		})
+	}
+}
+
+func TestEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	kv, err := NewFileKV(dir)
+	require.NoError(t, err)
+	store, err := NewEncryptedStore(kv, []byte("01234567890123456789012345678901"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "alice", &oauth2.Token{RefreshToken: "sams_rt_alice"}))
+
+	t.Run("encrypted at rest", func(t *testing.T) {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.NotContains(t, entries[0].Name(), "alice")
+		content, err := os.ReadFile(dir + "/" + entries[0].Name())
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "sams_rt_alice")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := NewEncryptedStore(kv, []byte("98765432109876543210987654321098"))
+		require.NoError(t, err)
+		_, err = other.Get(ctx, "alice")
+		assert.Error(t, err)
+	})
+
+	t.Run("ciphertext bound to user", func(t *testing.T) {
+		value, err := kv.Get(ctx, kvKey("alice"))
+		require.NoError(t, err)
+		require.NoError(t, kv.Set(ctx, kvKey("bob"), value))
+		_, err = store.Get(ctx, "bob")
+		assert.Error(t, err)
+	})
+
+	t.Run("compare and swap detects concurrent writes", func(t *testing.T) {
+		old, err := kv.Get(ctx, kvKey("alice"))
+		require.NoError(t, err)
+		// Re-encrypting the same token yields a different ciphertext.
+		require.NoError(t, store.Put(ctx, "alice", &oauth2.Token{RefreshToken: "sams_rt_alice"}))
+		assert.ErrorIs(t, kv.CompareAndSwap(ctx, kvKey("alice"), old, []byte("value")), ErrConflict)
+		assert.ErrorIs(t, kv.CompareAndSwap(ctx, kvKey("missing"), nil, []byte("value")), ErrConflict)
+	})
+
+	t.Run("invalid key size", func(t *testing.T) {
+		_, err := NewEncryptedStore(kv, []byte("too short"))
+		assert.Error(t, err)
+	})
+}
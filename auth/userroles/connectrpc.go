@@ -0,0 +1,144 @@
+package userroles
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/internal/connectutil"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+// Requirement is the role required to call an RPC.
+type Requirement struct {
+	// Role is the role the authenticated SAMS user must hold.
+	Role roles.Role
+	// ResourceID, if set, extracts the ID of the resource to check the role on
+	// from the request message, e.g. the subscription ID of a request to get a
+	// subscription. It is only supported for unary RPCs, and the role MUST be
+	// associated with a resource type other than roles.Service.
+	//
+	// If not set, the user may hold the role on any resource.
+	ResourceID func(msg any) (string, error)
+}
+
+// See userroles.NewInterceptor and userroles.NewSchemaInterceptor.
+type Interceptor struct {
+	authorizer *Authorizer
+	// requirements is the list of requirements of each procedure, all of which
+	// must be satisfied. An empty list only requires an authenticated user.
+	requirements map[string][]Requirement
+}
+
+// NewInterceptor creates a serverside ConnectRPC interceptor that ensures the
+// authenticated SAMS user of every incoming request holds the role required by
+// the RPC. Requirements are keyed by the full procedure name, which is
+// generated as constants like "UsersServiceGetUserProcedure" in your Connect
+// bindings.
+//
+// Requests to procedures without a requirement are rejected with an internal
+// error, declare a separate service that does not use this interceptor for
+// RPCs that do not require a role.
+func NewInterceptor(authorizer *Authorizer, requirements map[string]Requirement) (*Interceptor, error) {
+	all := make(map[string][]Requirement, len(requirements))
+	for procedure, requirement := range requirements {
+		if err := requirement.validate(); err != nil {
+			return nil, errors.Wrapf(err, "procedure %q", procedure)
+		}
+		all[procedure] = []Requirement{requirement}
+	}
+	return &Interceptor{
+		authorizer:   authorizer,
+		requirements: all,
+	}, nil
+}
+
+func (r Requirement) validate() error {
+	if !roles.Contains(r.Role) {
+		return errors.Newf("role %q is not registered", r.Role)
+	}
+	if r.ResourceID != nil && r.Role.ResourceType().IsService() {
+		return errors.Newf("role %q is not associated with a resource", r.Role)
+	}
+	return nil
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req) // no-op for clients
+		}
+		if err := i.requireRole(ctx, req.Spec().Procedure, req.Any()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		return next(ctx, spec) // no-op for clients
+	}
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if conn.Spec().IsClient {
+			return next(ctx, conn) // no-op for clients
+		}
+		if err := i.requireRole(ctx, conn.Spec().Procedure, nil); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// requireRole ensures the authenticated user holds the roles required by the
+// procedure. msg is nil for streaming RPCs. It returns a ConnectRPC status
+// error suitable to be returned directly from a ConnectRPC implementation.
+func (i *Interceptor) requireRole(ctx context.Context, procedure string, msg any) error {
+	logger := i.authorizer.logger.With(log.String("procedure", procedure))
+
+	requirements, ok := i.requirements[procedure]
+	if !ok {
+		return connectutil.InternalError(ctx, logger, errors.New("no role requirement declared for procedure"), "internal schema error")
+	}
+
+	userID := i.authorizer.userID(ctx)
+	if userID == "" {
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("user_id", userID))
+
+	for _, requirement := range requirements {
+		var resourceID string
+		if requirement.ResourceID != nil {
+			if msg == nil {
+				return connectutil.InternalError(ctx, logger, errors.New("resource ID is not supported for streaming RPCs"), "internal schema error")
+			}
+			var err error
+			resourceID, err = requirement.ResourceID(msg)
+			if err == nil && resourceID == "" {
+				err = errors.New("empty resource ID")
+			}
+			if err != nil {
+				return connect.NewError(connect.CodeInvalidArgument, errors.Wrap(err, "invalid resource ID"))
+			}
+		}
+
+		ok, err := i.authorizer.HasRole(ctx, userID, requirement.Role, resourceID)
+		if err != nil {
+			return connectutil.InternalError(ctx, logger, err, "unable to check user roles")
+		}
+		if !ok {
+			return connect.NewError(connect.CodePermissionDenied, errors.Newf("missing required role %q", requirement.Role))
+		}
+	}
+	return nil
+}
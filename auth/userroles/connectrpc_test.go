@@ -0,0 +1,156 @@
+package userroles
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+// userHeaderTransport sets the "X-User" header read by withUser.
+type userHeaderTransport struct {
+	userID string
+}
+
+func (t userHeaderTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("X-User", t.userID)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestInterceptor(t *testing.T) {
+	requirements := map[string]Requirement{
+		clientsv1connect.UsersServiceGetUserProcedure: {
+			Role: roles.RoleDotcomSiteAdmin,
+		},
+		// For testing purposes, treat the user ID as a subscription ID.
+		clientsv1connect.UsersServiceGetUserRolesProcedure: {
+			Role: roles.RoleEnterprisePortalCustomerAdmin,
+			ResourceID: func(msg any) (string, error) {
+				req, ok := msg.(*clientsv1.GetUserRolesRequest)
+				if !ok {
+					return "", errors.Newf("unexpected message %T", msg)
+				}
+				return req.GetId(), nil
+			},
+		},
+	}
+
+	getUser := func(c clientsv1connect.UsersServiceClient) error {
+		_, err := c.GetUser(context.Background(), connect.NewRequest(&clientsv1.GetUserRequest{}))
+		return err
+	}
+	getUserRoles := func(subscriptionID string) func(c clientsv1connect.UsersServiceClient) error {
+		return func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUserRoles(context.Background(), connect.NewRequest(&clientsv1.GetUserRolesRequest{Id: subscriptionID}))
+			return err
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		userID string
+		doRPC  func(c clientsv1connect.UsersServiceClient) error
+
+		wantError autogold.Value
+		wantLogs  autogold.Value
+	}{{
+		name:      "unauthenticated",
+		doRPC:     getUser,
+		wantError: autogold.Expect("unauthenticated: authentication required"),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name:      "has role",
+		userID:    "dotcom",
+		doRPC:     getUser,
+		wantError: autogold.Expect(nil),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name:      "missing role",
+		userID:    "customer",
+		doRPC:     getUser,
+		wantError: autogold.Expect(`permission_denied: missing required role "dotcom::site_admin"`),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name:      "has role on resource",
+		userID:    "customer",
+		doRPC:     getUserRoles("sub-1"),
+		wantError: autogold.Expect(nil),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name:      "missing role on resource",
+		userID:    "customer",
+		doRPC:     getUserRoles("sub-2"),
+		wantError: autogold.Expect(`permission_denied: missing required role "enterprise_portal::customer_admin"`),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name:      "empty resource ID",
+		userID:    "customer",
+		doRPC:     getUserRoles(""),
+		wantError: autogold.Expect("invalid_argument: invalid resource ID: empty resource ID"),
+		wantLogs:  autogold.Expect([]string{}),
+	}, {
+		name:   "no requirement",
+		userID: "dotcom",
+		doRPC: func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUserMetadata(context.Background(), connect.NewRequest(&clientsv1.GetUserMetadataRequest{}))
+			return err
+		},
+		wantError: autogold.Expect("internal: internal schema error"),
+		wantLogs:  autogold.Expect([]string{"internal schema error"}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, exportLogs := logtest.Captured(t)
+			interceptor, err := NewInterceptor(NewAuthorizer(logger, testUserRoles, Options{}), requirements)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.Handle(clientsv1connect.NewUsersServiceHandler(
+				clientsv1connect.UnimplementedUsersServiceHandler{},
+				connect.WithInterceptors(interceptor)))
+			srv := httptest.NewServer(withUser(mux))
+			t.Cleanup(srv.Close)
+
+			c := clientsv1connect.NewUsersServiceClient(
+				&http.Client{Transport: userHeaderTransport{userID: tc.userID}},
+				srv.URL)
+			err = tc.doRPC(c)
+
+			// Success cases are connect.CodeUnimplemented
+			require.Error(t, err)
+			if connect.CodeOf(err) == connect.CodeUnimplemented {
+				tc.wantError.Equal(t, nil) // should not expect an error
+			} else {
+				tc.wantError.Equal(t, err.Error())
+			}
+			tc.wantLogs.Equal(t, exportLogs().Messages())
+		})
+	}
+}
+
+func TestNewInterceptor(t *testing.T) {
+	a := NewAuthorizer(logtest.Scoped(t), testUserRoles, Options{})
+	_, err := NewInterceptor(a, map[string]Requirement{
+		"/foo": {Role: "dotcom::not_a_role"},
+	})
+	assert.EqualError(t, err, `procedure "/foo": role "dotcom::not_a_role" is not registered`)
+
+	_, err = NewInterceptor(a, map[string]Requirement{
+		"/foo": {
+			Role:       roles.RoleDotcomSiteAdmin,
+			ResourceID: func(any) (string, error) { return "", nil },
+		},
+	})
+	assert.EqualError(t, err, `procedure "/foo": role "dotcom::site_admin" is not associated with a resource`)
+}
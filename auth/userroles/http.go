@@ -0,0 +1,89 @@
+package userroles
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/internal/connectutil"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+// RequireRole only calls next if the authenticated SAMS user of the incoming
+// HTTP request holds the role. It responds with a 401 if the request is not
+// authenticated, or a 403 if the user does not hold the role.
+//
+// For a role associated with a resource type other than roles.Service, the
+// user may hold the role on any resource, use RequireResourceRole to check the
+// role on a particular resource instead.
+//
+// It panics if the role is not registered.
+func (a *Authorizer) RequireRole(role roles.Role, next http.Handler) http.Handler {
+	mustBeRegistered(role)
+	return a.requireRole(role, nil, next)
+}
+
+// RequireResourceRole is like RequireRole, but checks that the user holds the
+// role on the resource whose ID is extracted from the incoming HTTP request by
+// resourceID, e.g. using http.Request.PathValue. It responds with a 400 if
+// resourceID returns an error or an empty ID.
+//
+// It panics if the role is not registered.
+func (a *Authorizer) RequireResourceRole(role roles.Role, resourceID func(r *http.Request) (string, error), next http.Handler) http.Handler {
+	mustBeRegistered(role)
+	if resourceID == nil {
+		panic("resourceID is required")
+	}
+	return a.requireRole(role, resourceID, next)
+}
+
+func (a *Authorizer) requireRole(role roles.Role, resourceID func(r *http.Request) (string, error), next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := connectutil.LoggerWithTrace(ctx, a.logger).With(log.String("requiredRole", string(role)))
+
+		userID := a.userID(ctx)
+		if userID == "" {
+			logger.Debug("rejecting unauthenticated request")
+			const unauthorized = http.StatusUnauthorized
+			http.Error(w, http.StatusText(unauthorized), unauthorized)
+			return
+		}
+		logger = logger.With(log.String("userID", userID))
+
+		var id string
+		if resourceID != nil {
+			var err error
+			id, err = resourceID(r)
+			if err != nil || id == "" {
+				logger.Warn("error extracting resource ID", log.Error(err))
+				const badRequest = http.StatusBadRequest
+				http.Error(w, http.StatusText(badRequest), badRequest)
+				return
+			}
+			logger = logger.With(log.String("resourceID", id))
+		}
+
+		ok, err := a.HasRole(ctx, userID, role, id)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, context.Canceled) {
+				code = http.StatusBadRequest
+				logger.Warn("error checking user roles", log.Error(err))
+			} else {
+				logger.Error("error checking user roles", log.Error(err))
+			}
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+		if !ok {
+			logger.Warn("attempt to access without required role")
+			http.Error(w, "Forbidden: Missing required role", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
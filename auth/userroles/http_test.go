@@ -0,0 +1,177 @@
+package userroles
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth/sessions"
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+// withUser authenticates the request as the user in the "X-User" header, in
+// place of the auth/sessions middleware.
+func withUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userID := r.Header.Get("X-User"); userID != "" {
+			r = r.WithContext(sessions.WithSession(r.Context(), &clientsv1.Session{
+				User: &clientsv1.User{Id: userID},
+			}))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestHTTPAuthorizer(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	subscriptionID := func(r *http.Request) (string, error) {
+		return r.PathValue("subscriptionID"), nil
+	}
+
+	for _, tc := range []struct {
+		name      string
+		userRoles UserRolesGetter
+		handler   func(a *Authorizer) http.Handler
+		userID    string
+		path      string
+
+		wantResponse autogold.Value
+		wantLogs     autogold.Value
+	}{{
+		name:      "unauthenticated",
+		userRoles: testUserRoles,
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireRole(roles.RoleDotcomSiteAdmin, ok)
+		},
+		wantResponse: autogold.Expect("401 Unauthorized\n"),
+		wantLogs:     autogold.Expect([]string{"rejecting unauthenticated request"}),
+	}, {
+		name:      "has role",
+		userRoles: testUserRoles,
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireRole(roles.RoleDotcomSiteAdmin, ok)
+		},
+		userID:       "dotcom",
+		wantResponse: autogold.Expect("200 ok"),
+		wantLogs:     autogold.Expect([]string{}),
+	}, {
+		name:      "missing role",
+		userRoles: testUserRoles,
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireRole(roles.RoleDotcomSiteAdmin, ok)
+		},
+		userID:       "customer",
+		wantResponse: autogold.Expect("403 Forbidden: Missing required role\n"),
+		wantLogs:     autogold.Expect([]string{"attempt to access without required role"}),
+	}, {
+		name:      "has role on resource",
+		userRoles: testUserRoles,
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireResourceRole(roles.RoleEnterprisePortalCustomerAdmin, subscriptionID, ok)
+		},
+		userID:       "customer",
+		path:         "/subscriptions/sub-1",
+		wantResponse: autogold.Expect("200 ok"),
+		wantLogs:     autogold.Expect([]string{}),
+	}, {
+		name:      "missing role on resource",
+		userRoles: testUserRoles,
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireResourceRole(roles.RoleEnterprisePortalCustomerAdmin, subscriptionID, ok)
+		},
+		userID:       "customer",
+		path:         "/subscriptions/sub-2",
+		wantResponse: autogold.Expect("403 Forbidden: Missing required role\n"),
+		wantLogs:     autogold.Expect([]string{"attempt to access without required role"}),
+	}, {
+		name:      "invalid resource ID",
+		userRoles: testUserRoles,
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireResourceRole(roles.RoleEnterprisePortalCustomerAdmin, func(*http.Request) (string, error) {
+				return "", errors.New("malformed subscription ID")
+			}, ok)
+		},
+		userID:       "customer",
+		path:         "/subscriptions/sub-1",
+		wantResponse: autogold.Expect("400 Bad Request\n"),
+		wantLogs:     autogold.Expect([]string{"error extracting resource ID"}),
+	}, {
+		name:      "SAMS error",
+		userRoles: &mockUserRolesGetter{err: errors.New("sams is down")},
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireRole(roles.RoleDotcomSiteAdmin, ok)
+		},
+		userID:       "dotcom",
+		wantResponse: autogold.Expect("500 Internal Server Error\n"),
+		wantLogs:     autogold.Expect([]string{"error checking user roles"}),
+	}, {
+		name:      "context canceled",
+		userRoles: &mockUserRolesGetter{err: errors.Wrap(context.Canceled, "request canceled")},
+		handler: func(a *Authorizer) http.Handler {
+			return a.RequireRole(roles.RoleDotcomSiteAdmin, ok)
+		},
+		userID:       "dotcom",
+		wantResponse: autogold.Expect("400 Bad Request\n"),
+		wantLogs:     autogold.Expect([]string{"error checking user roles"}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, exportLogs := logtest.Captured(t)
+			a := NewAuthorizer(logger, tc.userRoles, Options{})
+
+			mux := http.NewServeMux()
+			mux.Handle("/subscriptions/{subscriptionID}", tc.handler(a))
+			mux.Handle("/", tc.handler(a))
+
+			path := tc.path
+			if path == "" {
+				path = "/"
+			}
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if tc.userID != "" {
+				req.Header.Set("X-User", tc.userID)
+			}
+			w := httptest.NewRecorder()
+			withUser(mux).ServeHTTP(w, req)
+
+			body, err := io.ReadAll(w.Result().Body)
+			require.NoError(t, err)
+			tc.wantResponse.Equal(t, w.Result().Status[:3]+" "+string(body))
+			tc.wantLogs.Equal(t, exportLogs().Messages())
+		})
+	}
+}
+
+func TestRequireRoleUnregistered(t *testing.T) {
+	a := NewAuthorizer(logtest.Scoped(t), testUserRoles, Options{})
+	assert.Panics(t, func() {
+		a.RequireRole("dotcom::not_a_role", http.NotFoundHandler())
+	})
+}
+
+func TestCustomUserID(t *testing.T) {
+	type userIDKey struct{}
+	a := NewAuthorizer(logtest.Scoped(t), testUserRoles, Options{
+		UserID: func(ctx context.Context) string {
+			userID, _ := ctx.Value(userIDKey{}).(string)
+			return userID
+		},
+	})
+	handler := a.RequireRole(roles.RoleDotcomSiteAdmin, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, "dotcom"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
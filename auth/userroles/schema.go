@@ -0,0 +1,146 @@
+package userroles
+
+import (
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+// SchemaExtensions are the MethodOptions extensions that declare the role
+// requirements of RPCs in a proto schema, see NewSchemaInterceptor.
+type SchemaExtensions struct {
+	// RequiredRoles is the `repeated string` extension that lists the roles the
+	// authenticated user must hold, e.g. E_SamsRequiredRoles.
+	RequiredRoles protoreflect.ExtensionType
+	// ResourceIDField is the `string` extension that names the request field that
+	// carries the resource ID, e.g. E_SamsResourceIdField. It may be nil if no
+	// RPC declares resource-scoped requirements.
+	ResourceIDField protoreflect.ExtensionType
+	// AuthenticatedOnly is the `bool` extension that explicitly allows any
+	// authenticated user, e.g. E_SamsAuthenticatedOnly. It may be nil if every
+	// RPC declares required roles.
+	AuthenticatedOnly protoreflect.ExtensionType
+}
+
+// NewSchemaInterceptor is like NewInterceptor, but the requirements are
+// declared in the proto schema of the given services, similar to required
+// scopes of clientcredentials.NewInterceptor.
+//
+// To declare required SAMS roles in your RPCs, add the following to your proto
+// schema, or import "clients/v1/clients.proto" and use clientsv1.E_SamsRequiredRoles,
+// clientsv1.E_SamsResourceIdField and clientsv1.E_SamsAuthenticatedOnly:
+//
+//	extend google.protobuf.MethodOptions {
+//		repeated string sams_required_roles = 50002;
+//		string sams_resource_id_field = 50003;
+//		bool sams_authenticated_only = 50004;
+//	}
+//
+// In your RPCs, add the `(sams_required_roles)` option, and the
+// `(sams_resource_id_field)` option to check resource-scoped roles on the
+// resource whose ID is in the named field of the request message, which is not
+// supported for streaming RPCs:
+//
+//	rpc GetSubscription(GetSubscriptionRequest) returns (GetSubscriptionResponse) {
+//		option (sams_required_roles) = "enterprise_portal::customer_admin";
+//		option (sams_resource_id_field) = "subscription_id";
+//	};
+//
+// RPCs that any authenticated user may use MUST be annotated with
+// `option (sams_authenticated_only) = true;` instead. It returns an error if any
+// RPC is not annotated or has a malformed annotation, e.g. an unregistered role
+// or a resource ID field that does not exist, so that misconfigurations are
+// caught at startup instead of allowing access by default.
+func NewSchemaInterceptor(authorizer *Authorizer, services []protoreflect.ServiceDescriptor, extensions SchemaExtensions) (*Interceptor, error) {
+	if extensions.RequiredRoles == nil {
+		return nil, errors.New("RequiredRoles extension is required")
+	}
+	requirements := make(map[string][]Requirement)
+	for _, service := range services {
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			procedure := fmt.Sprintf("/%s/%s", service.FullName(), method.Name())
+			methodRequirements, err := extractSchemaRequirements(method, extensions)
+			if err != nil {
+				return nil, errors.Wrapf(err, "procedure %q", procedure)
+			}
+			requirements[procedure] = methodRequirements
+		}
+	}
+	return &Interceptor{
+		authorizer:   authorizer,
+		requirements: requirements,
+	}, nil
+}
+
+func extractSchemaRequirements(method protoreflect.MethodDescriptor, extensions SchemaExtensions) ([]Requirement, error) {
+	options := method.Options()
+
+	var requiredRoles []roles.Role
+	if value := options.ProtoReflect().Get(extensions.RequiredRoles.TypeDescriptor()); value.IsValid() {
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			requiredRoles = append(requiredRoles, roles.Role(list.Get(i).String()))
+		}
+	}
+	var authenticatedOnly bool
+	if extensions.AuthenticatedOnly != nil {
+		authenticatedOnly = options.ProtoReflect().Get(extensions.AuthenticatedOnly.TypeDescriptor()).Bool()
+	}
+	switch {
+	case authenticatedOnly && len(requiredRoles) > 0:
+		return nil, errors.New("required roles cannot be declared on an RPC that only requires authentication")
+	case authenticatedOnly:
+		// An empty list of requirements only requires an authenticated user.
+		return []Requirement{}, nil
+	case len(requiredRoles) == 0:
+		return nil, errors.New("no required roles are declared, RPCs that any authenticated user may use must be declared explicitly with the AuthenticatedOnly extension")
+	}
+
+	var resourceID func(msg any) (string, error)
+	if extensions.ResourceIDField != nil {
+		if fieldName := options.ProtoReflect().Get(extensions.ResourceIDField.TypeDescriptor()).String(); fieldName != "" {
+			// Streaming RPCs are authorized before any request message is received.
+			if method.IsStreamingClient() || method.IsStreamingServer() {
+				return nil, errors.New("resource ID field is not supported for streaming RPCs")
+			}
+			field := method.Input().Fields().ByName(protoreflect.Name(fieldName))
+			if field == nil {
+				return nil, errors.Newf("resource ID field %q does not exist in %s", fieldName, method.Input().FullName())
+			}
+			if field.Kind() != protoreflect.StringKind || field.Cardinality() == protoreflect.Repeated {
+				return nil, errors.Newf("resource ID field %q must be a singular string field", fieldName)
+			}
+			resourceID = func(msg any) (string, error) {
+				m, ok := msg.(proto.Message)
+				if !ok || m.ProtoReflect().Descriptor().FullName() != method.Input().FullName() {
+					return "", errors.Newf("unexpected request message %T", msg)
+				}
+				return m.ProtoReflect().Get(field).String(), nil
+			}
+		}
+	}
+
+	requirements := make([]Requirement, 0, len(requiredRoles))
+	var resourceScoped bool
+	for _, role := range requiredRoles {
+		requirement := Requirement{Role: role}
+		if err := requirement.validate(); err != nil {
+			return nil, err
+		}
+		if resourceID != nil && !role.ResourceType().IsService() {
+			requirement.ResourceID = resourceID
+			resourceScoped = true
+		}
+		requirements = append(requirements, requirement)
+	}
+	if resourceID != nil && !resourceScoped {
+		return nil, errors.New("resource ID field is declared but no required role is associated with a resource")
+	}
+	return requirements, nil
+}
@@ -0,0 +1,328 @@
+package userroles
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
+)
+
+type methodAnnotation struct {
+	requiredRoles     []string
+	resourceIDField   string
+	authenticatedOnly bool
+	// serverStreaming turns the method into a server streaming RPC.
+	serverStreaming bool
+}
+
+// annotatedUsersService returns a copy of the UsersService descriptor with the
+// given method annotations, see annotatedService.
+func annotatedUsersService(t *testing.T, annotations map[string]methodAnnotation) protoreflect.ServiceDescriptor {
+	return annotatedService(t, "UsersService", annotations)
+}
+
+// annotatedService returns a copy of the descriptor of the named service in
+// clients.proto with the given method annotations, so that the generated
+// handler and client can be used against it. Methods without an annotation are
+// annotated as authenticated only.
+func annotatedService(t *testing.T, name string, annotations map[string]methodAnnotation) protoreflect.ServiceDescriptor {
+	t.Helper()
+
+	source := protodesc.ToFileDescriptorProto(clientsv1.File_clients_v1_clients_proto)
+	var service *descriptorpb.ServiceDescriptorProto
+	for _, s := range source.GetService() {
+		if s.GetName() == name {
+			service = proto.Clone(s).(*descriptorpb.ServiceDescriptorProto)
+		}
+	}
+	require.NotNil(t, service)
+	for _, method := range service.GetMethod() {
+		a, ok := annotations[method.GetName()]
+		if !ok {
+			a = methodAnnotation{authenticatedOnly: true}
+		}
+		options := &descriptorpb.MethodOptions{}
+		proto.SetExtension(options, clientsv1.E_SamsRequiredRoles, a.requiredRoles)
+		if a.resourceIDField != "" {
+			proto.SetExtension(options, clientsv1.E_SamsResourceIdField, a.resourceIDField)
+		}
+		if a.authenticatedOnly {
+			proto.SetExtension(options, clientsv1.E_SamsAuthenticatedOnly, true)
+		}
+		method.Options = options
+		if a.serverStreaming {
+			method.ServerStreaming = proto.Bool(true)
+		}
+	}
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("userroles_test_" + name + ".proto"),
+		Package:    source.Package,
+		Dependency: []string{source.GetName()},
+		Service:    []*descriptorpb.ServiceDescriptorProto{service},
+		Syntax:     proto.String("proto3"),
+	}, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	return file.Services().Get(0)
+}
+
+var testSchemaExtensions = SchemaExtensions{
+	RequiredRoles:     clientsv1.E_SamsRequiredRoles,
+	ResourceIDField:   clientsv1.E_SamsResourceIdField,
+	AuthenticatedOnly: clientsv1.E_SamsAuthenticatedOnly,
+}
+
+func TestSchemaInterceptor(t *testing.T) {
+	service := annotatedUsersService(t, map[string]methodAnnotation{
+		"GetUser": {requiredRoles: []string{"dotcom::site_admin"}},
+		// For testing purposes, treat the user ID as a subscription ID.
+		"GetUserRoles": {
+			requiredRoles:   []string{"enterprise_portal::customer_admin"},
+			resourceIDField: "id",
+		},
+		"GetUserMetadata": {authenticatedOnly: true},
+	})
+
+	for _, tc := range []struct {
+		name   string
+		userID string
+		doRPC  func(c clientsv1connect.UsersServiceClient) error
+
+		wantError autogold.Value
+	}{{
+		name: "unauthenticated",
+		doRPC: func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUserMetadata(context.Background(), connect.NewRequest(&clientsv1.GetUserMetadataRequest{}))
+			return err
+		},
+		wantError: autogold.Expect("unauthenticated: authentication required"),
+	}, {
+		name:   "authenticated without required roles",
+		userID: "customer",
+		doRPC: func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUserMetadata(context.Background(), connect.NewRequest(&clientsv1.GetUserMetadataRequest{}))
+			return err
+		},
+		wantError: autogold.Expect(nil),
+	}, {
+		name:   "missing role",
+		userID: "customer",
+		doRPC: func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUser(context.Background(), connect.NewRequest(&clientsv1.GetUserRequest{}))
+			return err
+		},
+		wantError: autogold.Expect(`permission_denied: missing required role "dotcom::site_admin"`),
+	}, {
+		name:   "has role on resource",
+		userID: "customer",
+		doRPC: func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUserRoles(context.Background(), connect.NewRequest(&clientsv1.GetUserRolesRequest{Id: "sub-1"}))
+			return err
+		},
+		wantError: autogold.Expect(nil),
+	}, {
+		name:   "missing role on resource",
+		userID: "customer",
+		doRPC: func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUserRoles(context.Background(), connect.NewRequest(&clientsv1.GetUserRolesRequest{Id: "sub-2"}))
+			return err
+		},
+		wantError: autogold.Expect(`permission_denied: missing required role "enterprise_portal::customer_admin"`),
+	}, {
+		name:   "implied role on resource",
+		userID: "admin",
+		doRPC: func(c clientsv1connect.UsersServiceClient) error {
+			_, err := c.GetUserRoles(context.Background(), connect.NewRequest(&clientsv1.GetUserRolesRequest{Id: "sub-2"}))
+			return err
+		},
+		wantError: autogold.Expect(nil),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logtest.Scoped(t)
+			interceptor, err := NewSchemaInterceptor(
+				NewAuthorizer(logger, testUserRoles, Options{}),
+				[]protoreflect.ServiceDescriptor{service},
+				testSchemaExtensions)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.Handle(clientsv1connect.NewUsersServiceHandler(
+				clientsv1connect.UnimplementedUsersServiceHandler{},
+				connect.WithInterceptors(interceptor)))
+			srv := httptest.NewServer(withUser(mux))
+			t.Cleanup(srv.Close)
+
+			c := clientsv1connect.NewUsersServiceClient(
+				&http.Client{Transport: userHeaderTransport{userID: tc.userID}},
+				srv.URL)
+			err = tc.doRPC(c)
+
+			// Success cases are connect.CodeUnimplemented
+			require.Error(t, err)
+			if connect.CodeOf(err) == connect.CodeUnimplemented {
+				tc.wantError.Equal(t, nil) // should not expect an error
+			} else {
+				tc.wantError.Equal(t, err.Error())
+			}
+		})
+	}
+}
+
+func TestSchemaInterceptorStreaming(t *testing.T) {
+	service := annotatedService(t, "RolesService", map[string]methodAnnotation{
+		"RegisterRoleResources": {requiredRoles: []string{"dotcom::site_admin"}},
+	})
+
+	for _, tc := range []struct {
+		name   string
+		userID string
+
+		wantError autogold.Value
+	}{{
+		name:      "unauthenticated",
+		wantError: autogold.Expect("unauthenticated: authentication required"),
+	}, {
+		name:      "missing role",
+		userID:    "customer",
+		wantError: autogold.Expect(`permission_denied: missing required role "dotcom::site_admin"`),
+	}, {
+		name:      "has role",
+		userID:    "dotcom",
+		wantError: autogold.Expect(nil),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logtest.Scoped(t)
+			interceptor, err := NewSchemaInterceptor(
+				NewAuthorizer(logger, testUserRoles, Options{}),
+				[]protoreflect.ServiceDescriptor{service},
+				testSchemaExtensions)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.Handle(clientsv1connect.NewRolesServiceHandler(
+				clientsv1connect.UnimplementedRolesServiceHandler{},
+				connect.WithInterceptors(interceptor)))
+			srv := httptest.NewServer(withUser(mux))
+			t.Cleanup(srv.Close)
+
+			c := clientsv1connect.NewRolesServiceClient(
+				&http.Client{Transport: userHeaderTransport{userID: tc.userID}},
+				srv.URL)
+			_, err = c.RegisterRoleResources(context.Background()).CloseAndReceive()
+
+			// Success cases are connect.CodeUnimplemented
+			require.Error(t, err)
+			if connect.CodeOf(err) == connect.CodeUnimplemented {
+				tc.wantError.Equal(t, nil) // should not expect an error
+			} else {
+				tc.wantError.Equal(t, err.Error())
+			}
+		})
+	}
+}
+
+func TestNewSchemaInterceptor(t *testing.T) {
+	a := NewAuthorizer(logtest.Scoped(t), testUserRoles, Options{})
+	for _, tc := range []struct {
+		name string
+		// service is the name of the service in clients.proto, the default of empty
+		// uses "UsersService".
+		service     string
+		annotations map[string]methodAnnotation
+		wantError   autogold.Value
+	}{{
+		name: "unregistered role",
+		annotations: map[string]methodAnnotation{
+			"GetUser": {requiredRoles: []string{"dotcom::not_a_role"}},
+		},
+		wantError: autogold.Expect(`procedure "/clients.v1.UsersService/GetUser": role "dotcom::not_a_role" is not registered`),
+	}, {
+		name: "unknown resource ID field",
+		annotations: map[string]methodAnnotation{
+			"GetUserRoles": {
+				requiredRoles:   []string{"enterprise_portal::customer_admin"},
+				resourceIDField: "subscription_id",
+			},
+		},
+		wantError: autogold.Expect(`procedure "/clients.v1.UsersService/GetUserRoles": resource ID field "subscription_id" does not exist in clients.v1.GetUserRolesRequest`),
+	}, {
+		name: "resource ID field without resource role",
+		annotations: map[string]methodAnnotation{
+			"GetUserRoles": {
+				requiredRoles:   []string{"dotcom::site_admin"},
+				resourceIDField: "id",
+			},
+		},
+		wantError: autogold.Expect(`procedure "/clients.v1.UsersService/GetUserRoles": resource ID field is declared but no required role is associated with a resource`),
+	}, {
+		name: "resource ID field on server streaming RPC",
+		annotations: map[string]methodAnnotation{
+			"GetUserRoles": {
+				requiredRoles:   []string{"enterprise_portal::customer_admin"},
+				resourceIDField: "id",
+				serverStreaming: true,
+			},
+		},
+		wantError: autogold.Expect(`procedure "/clients.v1.UsersService/GetUserRoles": resource ID field is not supported for streaming RPCs`),
+	}, {
+		name:    "resource ID field on client streaming RPC",
+		service: "RolesService",
+		annotations: map[string]methodAnnotation{
+			"RegisterRoleResources": {
+				requiredRoles:   []string{"enterprise_portal::customer_admin"},
+				resourceIDField: "resource_type",
+			},
+		},
+		wantError: autogold.Expect(`procedure "/clients.v1.RolesService/RegisterRoleResources": resource ID field is not supported for streaming RPCs`),
+	}, {
+		name: "not annotated",
+		annotations: map[string]methodAnnotation{
+			"GetUser": {},
+		},
+		wantError: autogold.Expect(`procedure "/clients.v1.UsersService/GetUser": no required roles are declared, RPCs that any authenticated user may use must be declared explicitly with the AuthenticatedOnly extension`),
+	}, {
+		name: "required roles and authenticated only",
+		annotations: map[string]methodAnnotation{
+			"GetUser": {requiredRoles: []string{"dotcom::site_admin"}, authenticatedOnly: true},
+		},
+		wantError: autogold.Expect(`procedure "/clients.v1.UsersService/GetUser": required roles cannot be declared on an RPC that only requires authentication`),
+	}, {
+		name: "valid",
+		annotations: map[string]methodAnnotation{
+			"GetUser": {requiredRoles: []string{"dotcom::site_admin"}},
+			"GetUserRoles": {
+				requiredRoles:   []string{"enterprise_portal::customer_admin"},
+				resourceIDField: "id",
+			},
+		},
+		wantError: autogold.Expect(nil),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			service := tc.service
+			if service == "" {
+				service = "UsersService"
+			}
+			_, err := NewSchemaInterceptor(a,
+				[]protoreflect.ServiceDescriptor{annotatedService(t, service, tc.annotations)},
+				testSchemaExtensions)
+			if err == nil {
+				tc.wantError.Equal(t, nil)
+			} else {
+				tc.wantError.Equal(t, err.Error())
+			}
+		})
+	}
+}
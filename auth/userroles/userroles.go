@@ -0,0 +1,112 @@
+// Package userroles provides HTTP middleware and a ConnectRPC interceptor that
+// authorize requests of authenticated SAMS users based on the roles they hold.
+package userroles
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth/sessions"
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+var tracer = otel.Tracer("sams/auth/userroles")
+
+type UserRolesGetter interface {
+	// GetUserRolesByID returns all roles that have been assigned to the SAMS
+	// user with the given ID for the service. This is generally implemented by
+	// *sams.UsersServiceV1, enable its UserRolesCacheSize to avoid calling SAMS
+	// for every request.
+	GetUserRolesByID(ctx context.Context, userID, service string) ([]*clientsv1.Role, error)
+}
+
+// Options configures userroles.NewAuthorizer.
+type Options struct {
+	// UserID returns the ID of the authenticated SAMS user from the request
+	// context, or an empty string if the request is not authenticated.
+	//
+	// The default uses the user set by the auth/sessions middleware, see
+	// sessions.UserFromContext.
+	UserID func(ctx context.Context) string
+}
+
+// Authorizer checks the roles of authenticated SAMS users, see
+// userroles.NewAuthorizer.
+type Authorizer struct {
+	logger    log.Logger
+	userRoles UserRolesGetter
+	userID    func(ctx context.Context) string
+}
+
+// NewAuthorizer returns an Authorizer that checks the roles of authenticated
+// SAMS users through the given UserRolesGetter. Use it to create HTTP
+// middleware with Authorizer.RequireRole and Authorizer.RequireResourceRole,
+// or a ConnectRPC interceptor with NewInterceptor.
+//
+// The provided logger is used to record internal-server errors.
+func NewAuthorizer(logger log.Logger, userRoles UserRolesGetter, opts Options) *Authorizer {
+	if opts.UserID == nil {
+		opts.UserID = func(ctx context.Context) string {
+			return sessions.UserFromContext(ctx).GetId()
+		}
+	}
+	return &Authorizer{
+		logger:    logger.Scoped("userroles"),
+		userRoles: userRoles,
+		userID:    opts.UserID,
+	}
+}
+
+// HasRole returns true if the user holds the role, either directly or through
+// a role that implies it (see roles.Implies).
+//
+// For a role associated with a resource type other than roles.Service, the
+// check is restricted to the resource with the given ID: the role must be held
+// on that resource, or be implied by a service-level role. An empty resourceID
+// matches the role held on any resource.
+func (a *Authorizer) HasRole(ctx context.Context, userID string, role roles.Role, resourceID string) (_ bool, err error) {
+	var span trace.Span
+	ctx, span = tracer.Start(ctx, "userroles.HasRole", trace.WithAttributes(
+		attribute.String("role", string(role)),
+		attribute.String("resource_id", resourceID)))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+
+	held, err := a.userRoles.GetUserRolesByID(ctx, userID, string(role.Service()))
+	if err != nil {
+		return false, errors.Wrap(err, "get user roles")
+	}
+	resourceType := role.ResourceType()
+	for _, h := range held {
+		heldRole := roles.Role(h.GetRoleId())
+		if !roles.Implies(heldRole, role) {
+			continue
+		}
+		if resourceType.IsService() || heldRole.ResourceType().IsService() {
+			return true, nil
+		}
+		if h.GetResourceType() == string(resourceType) &&
+			(resourceID == "" || h.GetResourceId() == resourceID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mustBeRegistered panics if the role is not registered, as a requirement on
+// an unknown role is a programming error that would reject every request.
+func mustBeRegistered(role roles.Role) {
+	if !roles.Contains(role) {
+		panic(errors.Newf("role %q is not registered", role))
+	}
+}
@@ -0,0 +1,128 @@
+package userroles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+type mockUserRolesGetter struct {
+	// roles is the roles of each user.
+	roles map[string][]*clientsv1.Role
+	err   error
+}
+
+func (m *mockUserRolesGetter) GetUserRolesByID(_ context.Context, userID, service string) ([]*clientsv1.Role, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var held []*clientsv1.Role
+	for _, role := range m.roles[userID] {
+		if role.GetService() == service {
+			held = append(held, role)
+		}
+	}
+	return held, nil
+}
+
+func customerAdminOf(subscriptionID string) *clientsv1.Role {
+	return &clientsv1.Role{
+		RoleId:       string(roles.RoleEnterprisePortalCustomerAdmin),
+		Service:      string(services.EnterprisePortal),
+		ResourceId:   pointers.Ptr(subscriptionID),
+		ResourceType: pointers.Ptr(string(roles.EnterpriseSubscription)),
+	}
+}
+
+func serviceRole(role roles.Role) *clientsv1.Role {
+	return &clientsv1.Role{
+		RoleId:  string(role),
+		Service: string(role.Service()),
+	}
+}
+
+var testUserRoles = &mockUserRolesGetter{
+	roles: map[string][]*clientsv1.Role{
+		"customer": {customerAdminOf("sub-1")},
+		"admin":    {serviceRole(roles.RoleEnterprisePortalServiceAdmin)},
+		"dotcom":   {serviceRole(roles.RoleDotcomSiteAdmin)},
+	},
+}
+
+func TestHasRole(t *testing.T) {
+	a := NewAuthorizer(logtest.Scoped(t), testUserRoles, Options{})
+	for _, tc := range []struct {
+		name       string
+		userID     string
+		role       roles.Role
+		resourceID string
+		want       bool
+	}{
+		{
+			name:   "service role held",
+			userID: "dotcom",
+			role:   roles.RoleDotcomSiteAdmin,
+			want:   true,
+		},
+		{
+			name:   "service role not held",
+			userID: "customer",
+			role:   roles.RoleDotcomSiteAdmin,
+			want:   false,
+		},
+		{
+			name:       "resource role held on resource",
+			userID:     "customer",
+			role:       roles.RoleEnterprisePortalCustomerAdmin,
+			resourceID: "sub-1",
+			want:       true,
+		},
+		{
+			name:       "resource role held on another resource",
+			userID:     "customer",
+			role:       roles.RoleEnterprisePortalCustomerAdmin,
+			resourceID: "sub-2",
+			want:       false,
+		},
+		{
+			name:   "resource role held on any resource",
+			userID: "customer",
+			role:   roles.RoleEnterprisePortalCustomerAdmin,
+			want:   true,
+		},
+		{
+			name:       "resource role implied by service role",
+			userID:     "admin",
+			role:       roles.RoleEnterprisePortalCustomerAdmin,
+			resourceID: "sub-2",
+			want:       true,
+		},
+		{
+			name:   "service role not implied by resource role",
+			userID: "customer",
+			role:   roles.RoleEnterprisePortalServiceAdmin,
+			want:   false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := a.HasRole(context.Background(), tc.userID, tc.role, tc.resourceID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		a := NewAuthorizer(logtest.Scoped(t), &mockUserRolesGetter{err: errors.New("sams is down")}, Options{})
+		_, err := a.HasRole(context.Background(), "dotcom", roles.RoleDotcomSiteAdmin, "")
+		assert.EqualError(t, err, "get user roles: sams is down")
+	})
+}
@@ -0,0 +1,77 @@
+package usertokens
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/internal/connectutil"
+)
+
+// See usertokens.NewInterceptor.
+type Interceptor struct {
+	authenticator *Authenticator
+}
+
+// NewInterceptor creates a serverside ConnectRPC interceptor that ensures every
+// incoming request carries a valid SAMS user access token, and makes the user
+// available via usertokens.UserFromContext.
+func NewInterceptor(authenticator *Authenticator) *Interceptor {
+	return &Interceptor{authenticator: authenticator}
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req) // no-op for clients
+		}
+		ctx, err := i.requireUser(ctx, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		return next(ctx, spec) // no-op for clients
+	}
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if conn.Spec().IsClient {
+			return next(ctx, conn) // no-op for clients
+		}
+		ctx, err := i.requireUser(ctx, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// requireUser returns a context with the user that owns the bearer token in
+// the headers. It returns a ConnectRPC status error suitable to be returned
+// directly from a ConnectRPC implementation.
+func (i *Interceptor) requireUser(ctx context.Context, headers http.Header) (context.Context, error) {
+	user, err := i.authenticator.authenticate(ctx, headers)
+	if err != nil {
+		if isUnauthenticated(err) {
+			return ctx, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		if errors.Is(err, errInsufficientScope) {
+			return ctx, connect.NewError(connect.CodePermissionDenied, err)
+		}
+		return ctx, connectutil.InternalError(ctx, i.authenticator.logger, err, "unable to validate token")
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("user_id", user.Sub))
+	return WithUser(ctx, user), nil
+}
@@ -0,0 +1,90 @@
+package usertokens
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
+)
+
+// usersService echoes the authenticated user ID.
+type usersService struct {
+	clientsv1connect.UnimplementedUsersServiceHandler
+}
+
+func (usersService) GetUser(ctx context.Context, _ *connect.Request[clientsv1.GetUserRequest]) (*connect.Response[clientsv1.GetUserResponse], error) {
+	return connect.NewResponse(&clientsv1.GetUserResponse{
+		User: &clientsv1.User{Id: UserFromContext(ctx).Sub},
+	}), nil
+}
+
+func TestInterceptor(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		token string
+
+		wantUserID autogold.Value
+		wantError  autogold.Value
+		wantLogs   autogold.Value
+	}{{
+		name:       "no token",
+		wantUserID: autogold.Expect(""),
+		wantError:  autogold.Expect("unauthenticated: no token provided in Authorization header"),
+		wantLogs:   autogold.Expect([]string{}),
+	}, {
+		name:       "invalid token",
+		token:      "mallory",
+		wantUserID: autogold.Expect(""),
+		wantError:  autogold.Expect("unauthenticated: invalid token"),
+		wantLogs:   autogold.Expect([]string{}),
+	}, {
+		name:       "insufficient scope",
+		token:      "unscoped",
+		wantUserID: autogold.Expect(""),
+		wantError:  autogold.Expect("permission_denied: token lacks the scope to read the user profile"),
+		wantLogs:   autogold.Expect([]string{}),
+	}, {
+		name:       "valid token",
+		token:      "alice",
+		wantUserID: autogold.Expect("alice-id"),
+		wantError:  autogold.Expect(nil),
+		wantLogs:   autogold.Expect([]string{}),
+	}, {
+		name:       "SAMS error",
+		token:      "broken",
+		wantUserID: autogold.Expect(""),
+		wantError:  autogold.Expect("internal: unable to validate token"),
+		wantLogs:   autogold.Expect([]string{"unable to validate token"}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, exportLogs := logtest.Captured(t)
+			interceptor := NewInterceptor(NewAuthenticator(logger, &mockUserGetter{}, Options{}))
+
+			mux := http.NewServeMux()
+			mux.Handle(clientsv1connect.NewUsersServiceHandler(usersService{}, connect.WithInterceptors(interceptor)))
+			srv := httptest.NewServer(mux)
+			t.Cleanup(srv.Close)
+
+			req := connect.NewRequest(&clientsv1.GetUserRequest{})
+			if tc.token != "" {
+				req.Header().Set("Authorization", "Bearer "+tc.token)
+			}
+			resp, err := clientsv1connect.NewUsersServiceClient(http.DefaultClient, srv.URL).GetUser(context.Background(), req)
+			if err != nil {
+				tc.wantError.Equal(t, err.Error())
+				tc.wantUserID.Equal(t, "")
+			} else {
+				tc.wantError.Equal(t, nil)
+				tc.wantUserID.Equal(t, resp.Msg.GetUser().GetId())
+			}
+			tc.wantLogs.Equal(t, exportLogs().Messages())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package usertokens
+
+import (
+	"context"
+
+	accountsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/accounts/v1"
+)
+
+type contextKey int
+
+const (
+	userKey contextKey = iota
+)
+
+// UserFromContext returns the authenticated SAMS user from the given context,
+// or nil if the request was not authenticated. This is generally set by
+// usertokens.Authenticator and usertokens.Interceptor.
+//
+// The returned user may be cached and shared with concurrent requests, and
+// MUST NOT be modified.
+func UserFromContext(ctx context.Context) *accountsv1.User {
+	user, _ := ctx.Value(userKey).(*accountsv1.User)
+	return user
+}
+
+// WithUser returns a new context with the given user.
+func WithUser(ctx context.Context, user *accountsv1.User) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
@@ -0,0 +1,71 @@
+package usertokens
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/auth"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/internal/connectutil"
+)
+
+// RequireUser only calls next if the incoming HTTP request carries a valid SAMS
+// user access token in the Authorization header. Otherwise, the configured
+// UnauthenticatedHandler is called instead.
+func (a *Authenticator) RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := connectutil.LoggerWithTrace(ctx, a.logger)
+
+		user, err := a.authenticate(ctx, r.Header)
+		if err != nil {
+			if isUnauthenticated(err) {
+				logger.Debug("rejecting unauthenticated request", log.Error(err))
+				ctx = auth.WithError(ctx, &auth.Error{
+					StatusCode: http.StatusUnauthorized,
+					Cause:      err,
+				})
+				a.opts.UnauthenticatedHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+			if errors.Is(err, errInsufficientScope) {
+				logger.Debug("rejecting request with insufficient scope", log.Error(err))
+				ctx = auth.WithError(ctx, &auth.Error{
+					StatusCode: http.StatusForbidden,
+					Cause:      err,
+				})
+				a.opts.UnauthenticatedHandler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			code := http.StatusInternalServerError
+			if errors.Is(err, context.Canceled) {
+				code = http.StatusBadRequest
+				logger.Warn("error authenticating user token", log.Error(err))
+			} else {
+				logger.Error("error authenticating user token", log.Error(err))
+			}
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithUser(ctx, user)))
+	})
+}
+
+// DefaultUnauthenticatedHandler responds with the status code extracted from
+// the context and a bearer challenge, without revealing the underlying error.
+var DefaultUnauthenticatedHandler = http.HandlerFunc(unauthenticatedHandler)
+
+func unauthenticatedHandler(w http.ResponseWriter, r *http.Request) {
+	code := auth.ErrorFromContext(r.Context()).StatusCode
+	switch code {
+	case http.StatusUnauthorized:
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	case http.StatusForbidden:
+		w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope"`)
+	}
+	http.Error(w, http.StatusText(code), code)
+}
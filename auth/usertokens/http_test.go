@@ -0,0 +1,90 @@
+package usertokens
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireUser(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		authorization string
+
+		wantStatus    autogold.Value
+		wantBody      autogold.Value
+		wantChallenge autogold.Value
+		wantLogs      autogold.Value
+	}{{
+		name:          "no token",
+		wantStatus:    autogold.Expect(401),
+		wantBody:      autogold.Expect("Unauthorized\n"),
+		wantChallenge: autogold.Expect(`Bearer error="invalid_token"`),
+		wantLogs:      autogold.Expect([]string{"rejecting unauthenticated request"}),
+	}, {
+		name:          "not a bearer token",
+		authorization: "Basic alice",
+		wantStatus:    autogold.Expect(401),
+		wantBody:      autogold.Expect("Unauthorized\n"),
+		wantChallenge: autogold.Expect(`Bearer error="invalid_token"`),
+		wantLogs:      autogold.Expect([]string{"rejecting unauthenticated request"}),
+	}, {
+		name:          "invalid token",
+		authorization: "Bearer mallory",
+		wantStatus:    autogold.Expect(401),
+		wantBody:      autogold.Expect("Unauthorized\n"),
+		wantChallenge: autogold.Expect(`Bearer error="invalid_token"`),
+		wantLogs:      autogold.Expect([]string{"rejecting unauthenticated request"}),
+	}, {
+		name:          "insufficient scope",
+		authorization: "Bearer unscoped",
+		wantStatus:    autogold.Expect(403),
+		wantBody:      autogold.Expect("Forbidden\n"),
+		wantChallenge: autogold.Expect(`Bearer error="insufficient_scope"`),
+		wantLogs:      autogold.Expect([]string{"rejecting request with insufficient scope"}),
+	}, {
+		name:          "valid token",
+		authorization: "Bearer alice",
+		wantStatus:    autogold.Expect(200),
+		wantBody:      autogold.Expect("alice-id"),
+		wantChallenge: autogold.Expect(""),
+		wantLogs:      autogold.Expect([]string{}),
+	}, {
+		name:          "SAMS error",
+		authorization: "Bearer broken",
+		wantStatus:    autogold.Expect(500),
+		wantBody:      autogold.Expect("Internal Server Error\n"),
+		wantChallenge: autogold.Expect(""),
+		wantLogs:      autogold.Expect([]string{"error authenticating user token"}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, exportLogs := logtest.Captured(t)
+			a := NewAuthenticator(logger, &mockUserGetter{}, Options{})
+			srv := httptest.NewServer(a.RequireUser(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(UserFromContext(r.Context()).Sub))
+			})))
+			t.Cleanup(srv.Close)
+
+			req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+			require.NoError(t, err)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			tc.wantStatus.Equal(t, resp.StatusCode)
+			tc.wantBody.Equal(t, string(body))
+			tc.wantChallenge.Equal(t, resp.Header.Get("WWW-Authenticate"))
+			tc.wantLogs.Equal(t, exportLogs().Messages())
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package usertokens provides HTTP middleware and a ConnectRPC interceptor that
+// authenticate requests carrying a SAMS user access token as a bearer token,
+// e.g. from browsers and CLIs, through the SAMS Accounts API.
+package usertokens
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"golang.org/x/oauth2"
+
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
+	accountsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/accounts/v1"
+)
+
+const (
+	defaultCacheTTL  = time.Minute
+	defaultCacheSize = 10000
+)
+
+// UserGetter looks up the SAMS user that owns an access token, which is used by
+// Authenticator to validate the tokens of incoming requests.
+type UserGetter interface {
+	// GetUser returns the SAMS user that owns the given access token. It returns
+	// an error matching accountsv1.ErrUnauthorized if the token is invalid,
+	// malformed, or expired, and accountsv1.ErrForbidden if the token lacks the
+	// scopes to read the user profile. This is generally implemented by
+	// NewAccountsV1UserGetter.
+	GetUser(ctx context.Context, accessToken string) (*accountsv1.User, error)
+}
+
+// NewAccountsV1UserGetter returns a UserGetter that calls the SAMS Accounts API
+// v1 with each access token. The HTTP client is optional.
+func NewAccountsV1UserGetter(conn sams.ConnConfig, httpClient *http.Client) (UserGetter, error) {
+	client, err := sams.NewAccountsV1(sams.AccountsV1Config{
+		ConnConfig: conn,
+		// The access token is provided on each call instead.
+		TokenSource: perCallTokenSource{},
+		HTTPClient:  httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &accountsV1UserGetter{client: client}, nil
+}
+
+type accountsV1UserGetter struct {
+	client *accountsv1.Client
+}
+
+func (g *accountsV1UserGetter) GetUser(ctx context.Context, accessToken string) (*accountsv1.User, error) {
+	return g.client.GetUserWithAccessToken(ctx, accessToken)
+}
+
+// perCallTokenSource is the token source of a client that is only used with
+// explicit access tokens.
+type perCallTokenSource struct{}
+
+func (perCallTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("the access token must be provided on each call")
+}
+
+// Options configures NewAuthenticator.
+type Options struct {
+	// CacheTTL is the duration to cache the user of a valid token, so that SAMS
+	// is not called on every request. Tokens revoked in SAMS remain usable for up
+	// to this long. The same applies to tokens that expire, as the expiry of an
+	// access token is not known to the Authenticator, so this SHOULD be much
+	// shorter than the lifetime of access tokens.
+	//
+	// The default of 0 uses 1 minute, and a negative value disables caching.
+	CacheTTL time.Duration
+	// CacheSize is the maximum number of cached tokens.
+	//
+	// The default of 0 (or less) uses 10,000.
+	CacheSize int
+	// UnauthenticatedHandler is the HTTP handler to call when RequireUser rejects
+	// a request. Use auth.ErrorFromContext to extract the error.
+	//
+	// The default responds with a plain 401 Unauthorized.
+	UnauthenticatedHandler http.Handler
+}
+
+// Authenticator authenticates SAMS user access tokens, see NewAuthenticator.
+type Authenticator struct {
+	logger log.Logger
+	users  UserGetter
+	opts   Options
+	// cache is keyed by the SHA-256 hash of the token, the token itself is never
+	// kept. It is nil if caching is disabled. Cached users are shared by all
+	// requests with the same token, see UserFromContext.
+	cache *expirable.LRU[string, *accountsv1.User]
+}
+
+// NewAuthenticator returns an Authenticator that validates SAMS user access
+// tokens with the given UserGetter, and makes the user available via
+// usertokens.UserFromContext. Use RequireUser for HTTP handlers, and
+// NewInterceptor for ConnectRPC services.
+//
+// The provided logger is used to record internal-server errors.
+func NewAuthenticator(logger log.Logger, users UserGetter, opts Options) *Authenticator {
+	if opts.CacheTTL == 0 {
+		opts.CacheTTL = defaultCacheTTL
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = defaultCacheSize
+	}
+	if opts.UnauthenticatedHandler == nil {
+		opts.UnauthenticatedHandler = DefaultUnauthenticatedHandler
+	}
+	a := &Authenticator{
+		logger: logger.Scoped("usertokens"),
+		users:  users,
+		opts:   opts,
+	}
+	if opts.CacheTTL > 0 {
+		a.cache = expirable.NewLRU[string, *accountsv1.User](opts.CacheSize, nil, opts.CacheTTL)
+	}
+	return a
+}
+
+var (
+	errNoToken           = errors.New("no token provided in Authorization header")
+	errInvalidToken      = errors.New("invalid token")
+	errInsufficientScope = errors.New("token lacks the scope to read the user profile")
+)
+
+// isUnauthenticated returns true if the request does not carry a valid token,
+// as opposed to failing to validate the token.
+func isUnauthenticated(err error) bool {
+	return errors.Is(err, errNoToken) || errors.Is(err, errInvalidToken)
+}
+
+// authenticate returns the user that owns the bearer token in the headers.
+func (a *Authenticator) authenticate(ctx context.Context, h http.Header) (*accountsv1.User, error) {
+	token, err := extractBearerToken(h)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	if a.cache != nil {
+		if user, ok := a.cache.Get(key); ok {
+			return user, nil
+		}
+	}
+
+	user, err := a.users.GetUser(ctx, token)
+	if err != nil {
+		if errors.Is(err, accountsv1.ErrUnauthorized) {
+			return nil, errInvalidToken
+		}
+		if errors.Is(err, accountsv1.ErrForbidden) {
+			return nil, errInsufficientScope
+		}
+		return nil, errors.Wrap(err, "get user")
+	}
+	if user == nil || user.Sub == "" {
+		return nil, errors.New("user has no ID")
+	}
+	if a.cache != nil {
+		_ = a.cache.Add(key, user)
+	}
+	return user, nil
+}
+
+func extractBearerToken(h http.Header) (string, error) {
+	typ, token, ok := strings.Cut(h.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(typ, "bearer") || strings.TrimSpace(token) == "" {
+		return "", errNoToken
+	}
+	return strings.TrimSpace(token), nil
+}
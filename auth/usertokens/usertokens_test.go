@@ -0,0 +1,118 @@
+package usertokens
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sams "github.com/sourcegraph/sourcegraph-accounts-sdk-go"
+	accountsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/accounts/v1"
+)
+
+// mockUserGetter accepts the tokens "alice" and "bob", fails on "broken", and
+// rejects "unscoped" as lacking the required scope.
+type mockUserGetter struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (m *mockUserGetter) GetUser(_ context.Context, accessToken string) (*accountsv1.User, error) {
+	m.mu.Lock()
+	m.calls++
+	m.mu.Unlock()
+
+	switch accessToken {
+	case "alice", "bob":
+		return &accountsv1.User{Sub: accessToken + "-id"}, nil
+	case "broken":
+		return nil, errors.New("SAMS is down")
+	case "unscoped":
+		return nil, &accountsv1.StatusError{StatusCode: http.StatusForbidden}
+	}
+	return nil, &accountsv1.StatusError{StatusCode: http.StatusUnauthorized}
+}
+
+func bearer(token string) http.Header {
+	h := http.Header{}
+	if token != "" {
+		h.Set("Authorization", "Bearer "+token)
+	}
+	return h
+}
+
+func TestAuthenticateCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cached", func(t *testing.T) {
+		users := &mockUserGetter{}
+		a := NewAuthenticator(logtest.Scoped(t), users, Options{})
+		for range 3 {
+			user, err := a.authenticate(ctx, bearer("alice"))
+			require.NoError(t, err)
+			assert.Equal(t, "alice-id", user.Sub)
+		}
+		user, err := a.authenticate(ctx, bearer("bob"))
+		require.NoError(t, err)
+		assert.Equal(t, "bob-id", user.Sub)
+		assert.Equal(t, 2, users.calls)
+		// Only token hashes are used as keys.
+		assert.NotContains(t, a.cache.Keys(), "alice")
+	})
+
+	t.Run("invalid tokens are not cached", func(t *testing.T) {
+		users := &mockUserGetter{}
+		a := NewAuthenticator(logtest.Scoped(t), users, Options{})
+		for range 2 {
+			_, err := a.authenticate(ctx, bearer("mallory"))
+			assert.ErrorIs(t, err, errInvalidToken)
+		}
+		assert.Equal(t, 2, users.calls)
+	})
+
+	t.Run("caching disabled", func(t *testing.T) {
+		users := &mockUserGetter{}
+		a := NewAuthenticator(logtest.Scoped(t), users, Options{CacheTTL: -1})
+		for range 2 {
+			_, err := a.authenticate(ctx, bearer("alice"))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, users.calls)
+	})
+}
+
+func TestAccountsV1UserGetter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer sams_at_valid":
+		case "Bearer sams_at_unscoped":
+			http.Error(w, "insufficient scope", http.StatusForbidden)
+			return
+		default:
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(accountsv1.User{Sub: "user-1"})
+	}))
+	t.Cleanup(srv.Close)
+
+	users, err := NewAccountsV1UserGetter(sams.ConnConfig{ExternalURL: srv.URL}, nil)
+	require.NoError(t, err)
+
+	user, err := users.GetUser(context.Background(), "sams_at_valid")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.Sub)
+
+	_, err = users.GetUser(context.Background(), "sams_at_invalid")
+	assert.ErrorIs(t, err, accountsv1.ErrUnauthorized)
+
+	_, err = users.GetUser(context.Background(), "sams_at_unscoped")
+	assert.ErrorIs(t, err, accountsv1.ErrForbidden)
+}
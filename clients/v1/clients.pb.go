@@ -1191,6 +1191,66 @@ func (*ErrorRecordMismatch) Descriptor() ([]byte, []int) {
 	return file_clients_v1_clients_proto_rawDescGZIP(), []int{20}
 }
 
+// ErrorRemovalThresholdExceeded is returned in the error details when a RegisterRoleResources
+// request would remove more than `max_removal_percent` of the existing resources, and no changes
+// were committed.
+type ErrorRemovalThresholdExceeded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of existing resources of the resource type before the request.
+	ExistingResourceCount uint64 `protobuf:"varint,1,opt,name=existing_resource_count,json=existingResourceCount,proto3" json:"existing_resource_count,omitempty"`
+	// The number of existing resources that would have been removed.
+	RemovedResourceCount uint64 `protobuf:"varint,2,opt,name=removed_resource_count,json=removedResourceCount,proto3" json:"removed_resource_count,omitempty"`
+}
+
+func (x *ErrorRemovalThresholdExceeded) Reset() {
+	*x = ErrorRemovalThresholdExceeded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clients_v1_clients_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorRemovalThresholdExceeded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorRemovalThresholdExceeded) ProtoMessage() {}
+
+func (x *ErrorRemovalThresholdExceeded) ProtoReflect() protoreflect.Message {
+	mi := &file_clients_v1_clients_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorRemovalThresholdExceeded.ProtoReflect.Descriptor instead.
+func (*ErrorRemovalThresholdExceeded) Descriptor() ([]byte, []int) {
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ErrorRemovalThresholdExceeded) GetExistingResourceCount() uint64 {
+	if x != nil {
+		return x.ExistingResourceCount
+	}
+	return 0
+}
+
+func (x *ErrorRemovalThresholdExceeded) GetRemovedResourceCount() uint64 {
+	if x != nil {
+		return x.RemovedResourceCount
+	}
+	return 0
+}
+
 type IntrospectTokenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1203,7 +1263,7 @@ type IntrospectTokenRequest struct {
 func (x *IntrospectTokenRequest) Reset() {
 	*x = IntrospectTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[21]
+		mi := &file_clients_v1_clients_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1216,7 +1276,7 @@ func (x *IntrospectTokenRequest) String() string {
 func (*IntrospectTokenRequest) ProtoMessage() {}
 
 func (x *IntrospectTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[21]
+	mi := &file_clients_v1_clients_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1229,7 +1289,7 @@ func (x *IntrospectTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntrospectTokenRequest.ProtoReflect.Descriptor instead.
 func (*IntrospectTokenRequest) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{21}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *IntrospectTokenRequest) GetToken() string {
@@ -1261,7 +1321,7 @@ type IntrospectTokenResponse struct {
 func (x *IntrospectTokenResponse) Reset() {
 	*x = IntrospectTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[22]
+		mi := &file_clients_v1_clients_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1274,7 +1334,7 @@ func (x *IntrospectTokenResponse) String() string {
 func (*IntrospectTokenResponse) ProtoMessage() {}
 
 func (x *IntrospectTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[22]
+	mi := &file_clients_v1_clients_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1287,7 +1347,7 @@ func (x *IntrospectTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntrospectTokenResponse.ProtoReflect.Descriptor instead.
 func (*IntrospectTokenResponse) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{22}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *IntrospectTokenResponse) GetActive() bool {
@@ -1325,6 +1385,101 @@ func (x *IntrospectTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+type GetRoleResourcesCapabilitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetRoleResourcesCapabilitiesRequest) Reset() {
+	*x = GetRoleResourcesCapabilitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clients_v1_clients_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoleResourcesCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleResourcesCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetRoleResourcesCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clients_v1_clients_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleResourcesCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetRoleResourcesCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{24}
+}
+
+type GetRoleResourcesCapabilitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether RegisterRoleResourcesRequestMetadata.dry_run is supported.
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Whether RegisterRoleResourcesRequestMetadata.max_removal_percent is supported.
+	MaxRemovalPercent bool `protobuf:"varint,2,opt,name=max_removal_percent,json=maxRemovalPercent,proto3" json:"max_removal_percent,omitempty"`
+}
+
+func (x *GetRoleResourcesCapabilitiesResponse) Reset() {
+	*x = GetRoleResourcesCapabilitiesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clients_v1_clients_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoleResourcesCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleResourcesCapabilitiesResponse) ProtoMessage() {}
+
+func (x *GetRoleResourcesCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_clients_v1_clients_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleResourcesCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetRoleResourcesCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRoleResourcesCapabilitiesResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *GetRoleResourcesCapabilitiesResponse) GetMaxRemovalPercent() bool {
+	if x != nil {
+		return x.MaxRemovalPercent
+	}
+	return false
+}
+
 type RegisterRoleResourcesRequestMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1337,12 +1492,20 @@ type RegisterRoleResourcesRequestMetadata struct {
 	// Should be a valid resource type as defined in the `roles` package:
 	// https://github.com/sourcegraph/sourcegraph-accounts-sdk-go/blob/main/roles/
 	ResourceType string `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	// If true, the changes the request would make are computed and returned in the
+	// response, but not committed.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// If greater than zero, the request is not committed when more than this
+	// percentage (1-100) of the existing `resource_type` resources would be removed.
+	// A FailedPrecondition error with the ErrorRemovalThresholdExceeded detail is
+	// returned instead.
+	MaxRemovalPercent uint32 `protobuf:"varint,4,opt,name=max_removal_percent,json=maxRemovalPercent,proto3" json:"max_removal_percent,omitempty"`
 }
 
 func (x *RegisterRoleResourcesRequestMetadata) Reset() {
 	*x = RegisterRoleResourcesRequestMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[23]
+		mi := &file_clients_v1_clients_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1355,7 +1518,7 @@ func (x *RegisterRoleResourcesRequestMetadata) String() string {
 func (*RegisterRoleResourcesRequestMetadata) ProtoMessage() {}
 
 func (x *RegisterRoleResourcesRequestMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[23]
+	mi := &file_clients_v1_clients_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1368,7 +1531,7 @@ func (x *RegisterRoleResourcesRequestMetadata) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use RegisterRoleResourcesRequestMetadata.ProtoReflect.Descriptor instead.
 func (*RegisterRoleResourcesRequestMetadata) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{23}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *RegisterRoleResourcesRequestMetadata) GetRevision() string {
@@ -1385,6 +1548,20 @@ func (x *RegisterRoleResourcesRequestMetadata) GetResourceType() string {
 	return ""
 }
 
+func (x *RegisterRoleResourcesRequestMetadata) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RegisterRoleResourcesRequestMetadata) GetMaxRemovalPercent() uint32 {
+	if x != nil {
+		return x.MaxRemovalPercent
+	}
+	return 0
+}
+
 type RegisterRoleResourcesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1400,7 +1577,7 @@ type RegisterRoleResourcesRequest struct {
 func (x *RegisterRoleResourcesRequest) Reset() {
 	*x = RegisterRoleResourcesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[24]
+		mi := &file_clients_v1_clients_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1413,7 +1590,7 @@ func (x *RegisterRoleResourcesRequest) String() string {
 func (*RegisterRoleResourcesRequest) ProtoMessage() {}
 
 func (x *RegisterRoleResourcesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[24]
+	mi := &file_clients_v1_clients_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1426,7 +1603,7 @@ func (x *RegisterRoleResourcesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRoleResourcesRequest.ProtoReflect.Descriptor instead.
 func (*RegisterRoleResourcesRequest) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{24}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{27}
 }
 
 func (m *RegisterRoleResourcesRequest) GetPayload() isRegisterRoleResourcesRequest_Payload {
@@ -1477,12 +1654,18 @@ type RegisterRoleResourcesResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	ResourceCount uint64 `protobuf:"varint,1,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	// Whether the request was a dry run, i.e. no changes were committed.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// IDs of resources that were added, or would be added in a dry run.
+	AddedResourceIds []string `protobuf:"bytes,3,rep,name=added_resource_ids,json=addedResourceIds,proto3" json:"added_resource_ids,omitempty"`
+	// IDs of resources that were removed, or would be removed in a dry run.
+	RemovedResourceIds []string `protobuf:"bytes,4,rep,name=removed_resource_ids,json=removedResourceIds,proto3" json:"removed_resource_ids,omitempty"`
 }
 
 func (x *RegisterRoleResourcesResponse) Reset() {
 	*x = RegisterRoleResourcesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[25]
+		mi := &file_clients_v1_clients_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1495,7 +1678,7 @@ func (x *RegisterRoleResourcesResponse) String() string {
 func (*RegisterRoleResourcesResponse) ProtoMessage() {}
 
 func (x *RegisterRoleResourcesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[25]
+	mi := &file_clients_v1_clients_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1508,7 +1691,7 @@ func (x *RegisterRoleResourcesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRoleResourcesResponse.ProtoReflect.Descriptor instead.
 func (*RegisterRoleResourcesResponse) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{25}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RegisterRoleResourcesResponse) GetResourceCount() uint64 {
@@ -1518,6 +1701,27 @@ func (x *RegisterRoleResourcesResponse) GetResourceCount() uint64 {
 	return 0
 }
 
+func (x *RegisterRoleResourcesResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RegisterRoleResourcesResponse) GetAddedResourceIds() []string {
+	if x != nil {
+		return x.AddedResourceIds
+	}
+	return nil
+}
+
+func (x *RegisterRoleResourcesResponse) GetRemovedResourceIds() []string {
+	if x != nil {
+		return x.RemovedResourceIds
+	}
+	return nil
+}
+
 type RoleResource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1532,7 +1736,7 @@ type RoleResource struct {
 func (x *RoleResource) Reset() {
 	*x = RoleResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[26]
+		mi := &file_clients_v1_clients_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1545,7 +1749,7 @@ func (x *RoleResource) String() string {
 func (*RoleResource) ProtoMessage() {}
 
 func (x *RoleResource) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[26]
+	mi := &file_clients_v1_clients_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1558,7 +1762,7 @@ func (x *RoleResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoleResource.ProtoReflect.Descriptor instead.
 func (*RoleResource) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{26}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *RoleResource) GetResourceId() string {
@@ -1599,7 +1803,7 @@ type ServiceAccessToken struct {
 func (x *ServiceAccessToken) Reset() {
 	*x = ServiceAccessToken{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[27]
+		mi := &file_clients_v1_clients_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1612,7 +1816,7 @@ func (x *ServiceAccessToken) String() string {
 func (*ServiceAccessToken) ProtoMessage() {}
 
 func (x *ServiceAccessToken) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[27]
+	mi := &file_clients_v1_clients_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1625,7 +1829,7 @@ func (x *ServiceAccessToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceAccessToken.ProtoReflect.Descriptor instead.
 func (*ServiceAccessToken) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{27}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ServiceAccessToken) GetId() string {
@@ -1694,7 +1898,7 @@ type CreateServiceAccessTokenRequest struct {
 func (x *CreateServiceAccessTokenRequest) Reset() {
 	*x = CreateServiceAccessTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[28]
+		mi := &file_clients_v1_clients_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1707,7 +1911,7 @@ func (x *CreateServiceAccessTokenRequest) String() string {
 func (*CreateServiceAccessTokenRequest) ProtoMessage() {}
 
 func (x *CreateServiceAccessTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[28]
+	mi := &file_clients_v1_clients_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1720,7 +1924,7 @@ func (x *CreateServiceAccessTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateServiceAccessTokenRequest.ProtoReflect.Descriptor instead.
 func (*CreateServiceAccessTokenRequest) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{28}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CreateServiceAccessTokenRequest) GetToken() *ServiceAccessToken {
@@ -1743,7 +1947,7 @@ type CreateServiceAccessTokenResponse struct {
 func (x *CreateServiceAccessTokenResponse) Reset() {
 	*x = CreateServiceAccessTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[29]
+		mi := &file_clients_v1_clients_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1756,7 +1960,7 @@ func (x *CreateServiceAccessTokenResponse) String() string {
 func (*CreateServiceAccessTokenResponse) ProtoMessage() {}
 
 func (x *CreateServiceAccessTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[29]
+	mi := &file_clients_v1_clients_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1769,7 +1973,7 @@ func (x *CreateServiceAccessTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateServiceAccessTokenResponse.ProtoReflect.Descriptor instead.
 func (*CreateServiceAccessTokenResponse) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{29}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CreateServiceAccessTokenResponse) GetToken() *ServiceAccessToken {
@@ -1811,7 +2015,7 @@ type ListServiceAccessTokensRequest struct {
 func (x *ListServiceAccessTokensRequest) Reset() {
 	*x = ListServiceAccessTokensRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[30]
+		mi := &file_clients_v1_clients_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1824,7 +2028,7 @@ func (x *ListServiceAccessTokensRequest) String() string {
 func (*ListServiceAccessTokensRequest) ProtoMessage() {}
 
 func (x *ListServiceAccessTokensRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[30]
+	mi := &file_clients_v1_clients_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1837,7 +2041,7 @@ func (x *ListServiceAccessTokensRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServiceAccessTokensRequest.ProtoReflect.Descriptor instead.
 func (*ListServiceAccessTokensRequest) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{30}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListServiceAccessTokensRequest) GetPageSize() int32 {
@@ -1877,7 +2081,7 @@ type ListServiceAccessTokensFilter struct {
 func (x *ListServiceAccessTokensFilter) Reset() {
 	*x = ListServiceAccessTokensFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[31]
+		mi := &file_clients_v1_clients_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1890,7 +2094,7 @@ func (x *ListServiceAccessTokensFilter) String() string {
 func (*ListServiceAccessTokensFilter) ProtoMessage() {}
 
 func (x *ListServiceAccessTokensFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[31]
+	mi := &file_clients_v1_clients_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1903,7 +2107,7 @@ func (x *ListServiceAccessTokensFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServiceAccessTokensFilter.ProtoReflect.Descriptor instead.
 func (*ListServiceAccessTokensFilter) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{31}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{34}
 }
 
 func (m *ListServiceAccessTokensFilter) GetFilter() isListServiceAccessTokensFilter_Filter {
@@ -1965,12 +2169,17 @@ type ListServiceAccessTokensResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	Tokens []*ServiceAccessToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	// A token, which can be sent as `page_token` to retrieve the next page. If
+	// this field is omitted, there are no subsequent pages.
+	//
+	// See pagination concepts from https://cloud.google.com/apis/design/design_patterns#list_pagination
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
 func (x *ListServiceAccessTokensResponse) Reset() {
 	*x = ListServiceAccessTokensResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[32]
+		mi := &file_clients_v1_clients_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1983,7 +2192,7 @@ func (x *ListServiceAccessTokensResponse) String() string {
 func (*ListServiceAccessTokensResponse) ProtoMessage() {}
 
 func (x *ListServiceAccessTokensResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[32]
+	mi := &file_clients_v1_clients_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1996,7 +2205,7 @@ func (x *ListServiceAccessTokensResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServiceAccessTokensResponse.ProtoReflect.Descriptor instead.
 func (*ListServiceAccessTokensResponse) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{32}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ListServiceAccessTokensResponse) GetTokens() []*ServiceAccessToken {
@@ -2006,6 +2215,13 @@ func (x *ListServiceAccessTokensResponse) GetTokens() []*ServiceAccessToken {
 	return nil
 }
 
+func (x *ListServiceAccessTokensResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type RevokeServiceAccessTokenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2018,7 +2234,7 @@ type RevokeServiceAccessTokenRequest struct {
 func (x *RevokeServiceAccessTokenRequest) Reset() {
 	*x = RevokeServiceAccessTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[33]
+		mi := &file_clients_v1_clients_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2031,7 +2247,7 @@ func (x *RevokeServiceAccessTokenRequest) String() string {
 func (*RevokeServiceAccessTokenRequest) ProtoMessage() {}
 
 func (x *RevokeServiceAccessTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[33]
+	mi := &file_clients_v1_clients_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2044,7 +2260,7 @@ func (x *RevokeServiceAccessTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeServiceAccessTokenRequest.ProtoReflect.Descriptor instead.
 func (*RevokeServiceAccessTokenRequest) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{33}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *RevokeServiceAccessTokenRequest) GetId() string {
@@ -2063,7 +2279,7 @@ type RevokeServiceAccessTokenResponse struct {
 func (x *RevokeServiceAccessTokenResponse) Reset() {
 	*x = RevokeServiceAccessTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[34]
+		mi := &file_clients_v1_clients_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2076,7 +2292,7 @@ func (x *RevokeServiceAccessTokenResponse) String() string {
 func (*RevokeServiceAccessTokenResponse) ProtoMessage() {}
 
 func (x *RevokeServiceAccessTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[34]
+	mi := &file_clients_v1_clients_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2089,7 +2305,7 @@ func (x *RevokeServiceAccessTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeServiceAccessTokenResponse.ProtoReflect.Descriptor instead.
 func (*RevokeServiceAccessTokenResponse) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{34}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{37}
 }
 
 type RegisterRoleResourcesRequest_Resources struct {
@@ -2103,7 +2319,7 @@ type RegisterRoleResourcesRequest_Resources struct {
 func (x *RegisterRoleResourcesRequest_Resources) Reset() {
 	*x = RegisterRoleResourcesRequest_Resources{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clients_v1_clients_proto_msgTypes[35]
+		mi := &file_clients_v1_clients_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2116,7 +2332,7 @@ func (x *RegisterRoleResourcesRequest_Resources) String() string {
 func (*RegisterRoleResourcesRequest_Resources) ProtoMessage() {}
 
 func (x *RegisterRoleResourcesRequest_Resources) ProtoReflect() protoreflect.Message {
-	mi := &file_clients_v1_clients_proto_msgTypes[35]
+	mi := &file_clients_v1_clients_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2129,7 +2345,7 @@ func (x *RegisterRoleResourcesRequest_Resources) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use RegisterRoleResourcesRequest_Resources.ProtoReflect.Descriptor instead.
 func (*RegisterRoleResourcesRequest_Resources) Descriptor() ([]byte, []int) {
-	return file_clients_v1_clients_proto_rawDescGZIP(), []int{24, 0}
+	return file_clients_v1_clients_proto_rawDescGZIP(), []int{27, 0}
 }
 
 func (x *RegisterRoleResourcesRequest_Resources) GetResources() []*RoleResource {
@@ -2148,6 +2364,30 @@ var file_clients_v1_clients_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,50001,rep,name=sams_required_scopes",
 		Filename:      "clients/v1/clients.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         50002,
+		Name:          "clients.v1.sams_required_roles",
+		Tag:           "bytes,50002,rep,name=sams_required_roles",
+		Filename:      "clients/v1/clients.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50003,
+		Name:          "clients.v1.sams_resource_id_field",
+		Tag:           "bytes,50003,opt,name=sams_resource_id_field",
+		Filename:      "clients/v1/clients.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50004,
+		Name:          "clients.v1.sams_authenticated_only",
+		Tag:           "varint,50004,opt,name=sams_authenticated_only",
+		Filename:      "clients/v1/clients.proto",
+	},
 }
 
 // Extension fields to descriptorpb.MethodOptions.
@@ -2159,6 +2399,22 @@ var (
 	//
 	// repeated string sams_required_scopes = 50001;
 	E_SamsRequiredScopes = &file_clients_v1_clients_proto_extTypes[0]
+	// The SAMS roles the authenticated user must hold to use this RPC, e.g.
+	// "dotcom::site_admin".
+	//
+	// repeated string sams_required_roles = 50002;
+	E_SamsRequiredRoles = &file_clients_v1_clients_proto_extTypes[1]
+	// The name of the request message field that carries the ID of the resource to
+	// check resource-scoped `sams_required_roles` on. The field must be a singular
+	// string field.
+	//
+	// optional string sams_resource_id_field = 50003;
+	E_SamsResourceIdField = &file_clients_v1_clients_proto_extTypes[2]
+	// Whether any authenticated user may use this RPC, which must be declared
+	// explicitly in place of `sams_required_roles`.
+	//
+	// optional bool sams_authenticated_only = 50004;
+	E_SamsAuthenticatedOnly = &file_clients_v1_clients_proto_extTypes[3]
 )
 
 var File_clients_v1_clients_proto protoreflect.FileDescriptor
@@ -2278,7 +2534,16 @@ var file_clients_v1_clients_proto_rawDesc = []byte{
 	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x18, 0x0a, 0x16, 0x53,
 	0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x2e, 0x0a, 0x16,
+	0x63, 0x6f, 0x72, 0x64, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x8d, 0x01, 0x0a,
+	0x1d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x36,
+	0x0a, 0x17, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x15, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x2e, 0x0a, 0x16,
 	0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xba, 0x01, 0x0a,
@@ -2293,204 +2558,255 @@ var file_clients_v1_clients_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
-	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x67, 0x0a, 0x24, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a,
-	0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x22, 0x92, 0x02, 0x0a, 0x1c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
-	0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x52, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x43, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x42, 0x09, 0x0a, 0x07,
-	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x46, 0x0a, 0x1d, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
-	0x52, 0x0a, 0x0c, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
-	0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64,
-	0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e,
-	0x61, 0x6d, 0x65, 0x22, 0x90, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78, 0x70,
-	0x69, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69,
-	0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x57, 0x0a, 0x1f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
-	0x70, 0x0a, 0x20, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x22, 0xa1, 0x01, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x12, 0x43, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x25, 0x0a, 0x23, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x6f, 0x0a, 0x24, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c,
+	0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x22, 0xb0, 0x01, 0x0a, 0x24, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x61, 0x6c, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x22, 0x92, 0x02, 0x0a, 0x1c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c,
+	0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x52, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52, 0x09,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x43, 0x0a, 0x09, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xbf, 0x01, 0x0a, 0x1d, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x61,
+	0x64, 0x64, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x61, 0x64, 0x64, 0x65, 0x64, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x52, 0x0a, 0x0c, 0x52,
+	0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22,
+	0x90, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0x57, 0x0a, 0x1f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x70, 0x0a, 0x20, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x34, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0xa1, 0x01,
+	0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x43, 0x0a, 0x07,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x22, 0x85, 0x01, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x19, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0c, 0x73, 0x68,
+	0x6f, 0x77, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x00, 0x52, 0x0b, 0x73, 0x68, 0x6f, 0x77, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x42,
+	0x08, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x81, 0x01, 0x0a, 0x1f, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a,
+	0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x06, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x31, 0x0a,
+	0x1f, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x22, 0x0a, 0x20, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0xcb, 0x04, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x73, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0b, 0x8a, 0xb5, 0x18, 0x07, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x52, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x12, 0x1b, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0b, 0x8a,
+	0xb5, 0x18, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x62, 0x0a, 0x0a, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x8a, 0xb5, 0x18, 0x11, 0x73, 0x61, 0x6d,
+	0x73, 0x3a, 0x3a, 0x75, 0x73, 0x65, 0x72, 0x3a, 0x3a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x12, 0x6d,
+	0x0a, 0x0c, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x1f,
+	0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1a, 0x8a, 0xb5, 0x18, 0x16, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x3a, 0x3a, 0x72, 0x65, 0x61, 0x64, 0x12, 0x5c, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x22, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x12, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x25, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x32, 0xea, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x64, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x17, 0x8a, 0xb5, 0x18, 0x13, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x3a, 0x72, 0x65, 0x61, 0x64, 0x12, 0x71, 0x0a, 0x0e,
+	0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21,
+	0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x4f, 0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x8a, 0xb5, 0x18, 0x14, 0x73, 0x61, 0x6d, 0x73, 0x3a,
+	0x3a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x3a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x32,
+	0x6b, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x5a, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x22, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xca, 0x02, 0x0a,
+	0x0c, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x90, 0x01,
+	0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c,
+	0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x29, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x8a, 0xb5,
+	0x18, 0x1c, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x3a, 0x3a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x28, 0x01,
+	0x12, 0xa6, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x2f, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x30, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x8a, 0xb5, 0x18, 0x1c, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a,
+	0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x3a,
+	0x3a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x90, 0x02, 0x01, 0x32, 0x8a, 0x03, 0x0a, 0x1a, 0x53, 0x65,
 	0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23,
-	0x0a, 0x0c, 0x73, 0x68, 0x6f, 0x77, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x68, 0x6f, 0x77, 0x45, 0x78, 0x70, 0x69,
-	0x72, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x59, 0x0a,
-	0x1f, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x36, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1e, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x77, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x77, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x2a, 0x2e, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
 	0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x22, 0x31, 0x0a, 0x1f, 0x52, 0x65, 0x76, 0x6f,
-	0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x22, 0x0a, 0x20, 0x52,
-	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0xcb, 0x04, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x4f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x63, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0b, 0x8a, 0xb5, 0x18, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x12, 0x52, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x2e,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73,
-	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0b, 0x8a, 0xb5, 0x18, 0x07, 0x70, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x62, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55,
-	0x73, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x15, 0x8a, 0xb5, 0x18, 0x11, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a, 0x75, 0x73,
-	0x65, 0x72, 0x3a, 0x3a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x12, 0x6d, 0x0a, 0x0c, 0x47, 0x65, 0x74,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f,
-	0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52,
-	0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x8a, 0xb5,
-	0x18, 0x16, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x72, 0x6f, 0x6c,
-	0x65, 0x73, 0x3a, 0x3a, 0x72, 0x65, 0x61, 0x64, 0x12, 0x5c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55,
-	0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x2e, 0x63, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x25, 0x2e, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xea, 0x01,
-	0x0a, 0x0f, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x64, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x1d, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
-	0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17,
-	0x8a, 0xb5, 0x18, 0x13, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x3a, 0x3a, 0x72, 0x65, 0x61, 0x64, 0x12, 0x71, 0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x4f,
-	0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x63, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75,
-	0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x18, 0x8a, 0xb5, 0x18, 0x14, 0x73, 0x61, 0x6d, 0x73, 0x3a, 0x3a, 0x73, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x3a, 0x3a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x32, 0x6b, 0x0a, 0x0d, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5a, 0x0a, 0x0f, 0x49,
-	0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22,
-	0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x72,
-	0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xa1, 0x01, 0x0a, 0x0c, 0x52, 0x6f, 0x6c, 0x65,
-	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x90, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x12, 0x28, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x8a, 0xb5, 0x18, 0x1c, 0x73, 0x61, 0x6d,
-	0x73, 0x3a, 0x3a, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x3a, 0x3a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x28, 0x01, 0x32, 0x8a, 0x03, 0x0a, 0x1a,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x77, 0x0a, 0x18, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x7a, 0x0a, 0x18, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73,
 	0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
 	0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63,
+	0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63,
 	0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x77, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x2a,
-	0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x7a, 0x0a, 0x18,
-	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x02, 0x3a, 0x52, 0x0a, 0x14, 0x73, 0x61, 0x6d, 0x73,
-	0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73,
-	0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0xd1, 0x86, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x73, 0x61, 0x6d, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x42, 0x49, 0x5a, 0x47,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x2d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2d, 0x73, 0x64, 0x6b, 0x2d,
-	0x67, 0x6f, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x22, 0x03, 0x90, 0x02, 0x02, 0x3a, 0x52, 0x0a, 0x14, 0x73, 0x61, 0x6d, 0x73, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x1e,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd1,
+	0x86, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x73, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x3a, 0x50, 0x0a, 0x13, 0x73, 0x61,
+	0x6d, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6c, 0x65,
+	0x73, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x73, 0x61, 0x6d, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x3a, 0x55, 0x0a, 0x16,
+	0x73, 0x61, 0x6d, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd3, 0x86, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13,
+	0x73, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x3a, 0x58, 0x0a, 0x17, 0x73, 0x61, 0x6d, 0x73, 0x5f, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x12, 0x1e,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd4,
+	0x86, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x73, 0x61, 0x6d, 0x73, 0x41, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x49, 0x5a,
+	0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2d, 0x73, 0x64, 0x6b,
+	0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2505,7 +2821,7 @@ func file_clients_v1_clients_proto_rawDescGZIP() []byte {
 	return file_clients_v1_clients_proto_rawDescData
 }
 
-var file_clients_v1_clients_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_clients_v1_clients_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_clients_v1_clients_proto_goTypes = []interface{}{
 	(*GetUserRequest)(nil),                         // 0: clients.v1.GetUserRequest
 	(*User)(nil),                                   // 1: clients.v1.User
@@ -2528,79 +2844,87 @@ var file_clients_v1_clients_proto_goTypes = []interface{}{
 	(*SignOutSessionRequest)(nil),                  // 18: clients.v1.SignOutSessionRequest
 	(*SignOutSessionResponse)(nil),                 // 19: clients.v1.SignOutSessionResponse
 	(*ErrorRecordMismatch)(nil),                    // 20: clients.v1.ErrorRecordMismatch
-	(*IntrospectTokenRequest)(nil),                 // 21: clients.v1.IntrospectTokenRequest
-	(*IntrospectTokenResponse)(nil),                // 22: clients.v1.IntrospectTokenResponse
-	(*RegisterRoleResourcesRequestMetadata)(nil),   // 23: clients.v1.RegisterRoleResourcesRequestMetadata
-	(*RegisterRoleResourcesRequest)(nil),           // 24: clients.v1.RegisterRoleResourcesRequest
-	(*RegisterRoleResourcesResponse)(nil),          // 25: clients.v1.RegisterRoleResourcesResponse
-	(*RoleResource)(nil),                           // 26: clients.v1.RoleResource
-	(*ServiceAccessToken)(nil),                     // 27: clients.v1.ServiceAccessToken
-	(*CreateServiceAccessTokenRequest)(nil),        // 28: clients.v1.CreateServiceAccessTokenRequest
-	(*CreateServiceAccessTokenResponse)(nil),       // 29: clients.v1.CreateServiceAccessTokenResponse
-	(*ListServiceAccessTokensRequest)(nil),         // 30: clients.v1.ListServiceAccessTokensRequest
-	(*ListServiceAccessTokensFilter)(nil),          // 31: clients.v1.ListServiceAccessTokensFilter
-	(*ListServiceAccessTokensResponse)(nil),        // 32: clients.v1.ListServiceAccessTokensResponse
-	(*RevokeServiceAccessTokenRequest)(nil),        // 33: clients.v1.RevokeServiceAccessTokenRequest
-	(*RevokeServiceAccessTokenResponse)(nil),       // 34: clients.v1.RevokeServiceAccessTokenResponse
-	(*RegisterRoleResourcesRequest_Resources)(nil), // 35: clients.v1.RegisterRoleResourcesRequest.Resources
-	(*timestamppb.Timestamp)(nil),                  // 36: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),                        // 37: google.protobuf.Struct
-	(*descriptorpb.MethodOptions)(nil),             // 38: google.protobuf.MethodOptions
+	(*ErrorRemovalThresholdExceeded)(nil),          // 21: clients.v1.ErrorRemovalThresholdExceeded
+	(*IntrospectTokenRequest)(nil),                 // 22: clients.v1.IntrospectTokenRequest
+	(*IntrospectTokenResponse)(nil),                // 23: clients.v1.IntrospectTokenResponse
+	(*GetRoleResourcesCapabilitiesRequest)(nil),    // 24: clients.v1.GetRoleResourcesCapabilitiesRequest
+	(*GetRoleResourcesCapabilitiesResponse)(nil),   // 25: clients.v1.GetRoleResourcesCapabilitiesResponse
+	(*RegisterRoleResourcesRequestMetadata)(nil),   // 26: clients.v1.RegisterRoleResourcesRequestMetadata
+	(*RegisterRoleResourcesRequest)(nil),           // 27: clients.v1.RegisterRoleResourcesRequest
+	(*RegisterRoleResourcesResponse)(nil),          // 28: clients.v1.RegisterRoleResourcesResponse
+	(*RoleResource)(nil),                           // 29: clients.v1.RoleResource
+	(*ServiceAccessToken)(nil),                     // 30: clients.v1.ServiceAccessToken
+	(*CreateServiceAccessTokenRequest)(nil),        // 31: clients.v1.CreateServiceAccessTokenRequest
+	(*CreateServiceAccessTokenResponse)(nil),       // 32: clients.v1.CreateServiceAccessTokenResponse
+	(*ListServiceAccessTokensRequest)(nil),         // 33: clients.v1.ListServiceAccessTokensRequest
+	(*ListServiceAccessTokensFilter)(nil),          // 34: clients.v1.ListServiceAccessTokensFilter
+	(*ListServiceAccessTokensResponse)(nil),        // 35: clients.v1.ListServiceAccessTokensResponse
+	(*RevokeServiceAccessTokenRequest)(nil),        // 36: clients.v1.RevokeServiceAccessTokenRequest
+	(*RevokeServiceAccessTokenResponse)(nil),       // 37: clients.v1.RevokeServiceAccessTokenResponse
+	(*RegisterRoleResourcesRequest_Resources)(nil), // 38: clients.v1.RegisterRoleResourcesRequest.Resources
+	(*timestamppb.Timestamp)(nil),                  // 39: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                        // 40: google.protobuf.Struct
+	(*descriptorpb.MethodOptions)(nil),             // 41: google.protobuf.MethodOptions
 }
 var file_clients_v1_clients_proto_depIdxs = []int32{
-	36, // 0: clients.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	36, // 1: clients.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 0: clients.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	39, // 1: clients.v1.User.updated_at:type_name -> google.protobuf.Timestamp
 	1,  // 2: clients.v1.GetUserResponse.user:type_name -> clients.v1.User
 	1,  // 3: clients.v1.GetUsersResponse.users:type_name -> clients.v1.User
 	7,  // 4: clients.v1.GetUserRolesResponse.user_roles:type_name -> clients.v1.Role
-	37, // 5: clients.v1.UserServiceMetadata.metadata:type_name -> google.protobuf.Struct
+	40, // 5: clients.v1.UserServiceMetadata.metadata:type_name -> google.protobuf.Struct
 	9,  // 6: clients.v1.GetUserMetadataResponse.metadata:type_name -> clients.v1.UserServiceMetadata
 	9,  // 7: clients.v1.UpdateUserMetadataRequest.metadata:type_name -> clients.v1.UserServiceMetadata
 	9,  // 8: clients.v1.UpdateUserMetadataResponse.metadata:type_name -> clients.v1.UserServiceMetadata
 	1,  // 9: clients.v1.CreateUserResponse.user:type_name -> clients.v1.User
 	1,  // 10: clients.v1.Session.user:type_name -> clients.v1.User
 	16, // 11: clients.v1.GetSessionResponse.session:type_name -> clients.v1.Session
-	36, // 12: clients.v1.IntrospectTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	23, // 13: clients.v1.RegisterRoleResourcesRequest.metadata:type_name -> clients.v1.RegisterRoleResourcesRequestMetadata
-	35, // 14: clients.v1.RegisterRoleResourcesRequest.resources:type_name -> clients.v1.RegisterRoleResourcesRequest.Resources
-	36, // 15: clients.v1.ServiceAccessToken.creation_time:type_name -> google.protobuf.Timestamp
-	36, // 16: clients.v1.ServiceAccessToken.expire_time:type_name -> google.protobuf.Timestamp
-	27, // 17: clients.v1.CreateServiceAccessTokenRequest.token:type_name -> clients.v1.ServiceAccessToken
-	27, // 18: clients.v1.CreateServiceAccessTokenResponse.token:type_name -> clients.v1.ServiceAccessToken
-	31, // 19: clients.v1.ListServiceAccessTokensRequest.filters:type_name -> clients.v1.ListServiceAccessTokensFilter
-	27, // 20: clients.v1.ListServiceAccessTokensResponse.tokens:type_name -> clients.v1.ServiceAccessToken
-	26, // 21: clients.v1.RegisterRoleResourcesRequest.Resources.resources:type_name -> clients.v1.RoleResource
-	38, // 22: clients.v1.sams_required_scopes:extendee -> google.protobuf.MethodOptions
-	0,  // 23: clients.v1.UsersService.GetUser:input_type -> clients.v1.GetUserRequest
-	3,  // 24: clients.v1.UsersService.GetUsers:input_type -> clients.v1.GetUsersRequest
-	13, // 25: clients.v1.UsersService.CreateUser:input_type -> clients.v1.CreateUserRequest
-	5,  // 26: clients.v1.UsersService.GetUserRoles:input_type -> clients.v1.GetUserRolesRequest
-	8,  // 27: clients.v1.UsersService.GetUserMetadata:input_type -> clients.v1.GetUserMetadataRequest
-	11, // 28: clients.v1.UsersService.UpdateUserMetadata:input_type -> clients.v1.UpdateUserMetadataRequest
-	15, // 29: clients.v1.SessionsService.GetSession:input_type -> clients.v1.GetSessionRequest
-	18, // 30: clients.v1.SessionsService.SignOutSession:input_type -> clients.v1.SignOutSessionRequest
-	21, // 31: clients.v1.TokensService.IntrospectToken:input_type -> clients.v1.IntrospectTokenRequest
-	24, // 32: clients.v1.RolesService.RegisterRoleResources:input_type -> clients.v1.RegisterRoleResourcesRequest
-	28, // 33: clients.v1.ServiceAccessTokensService.CreateServiceAccessToken:input_type -> clients.v1.CreateServiceAccessTokenRequest
-	30, // 34: clients.v1.ServiceAccessTokensService.ListServiceAccessTokens:input_type -> clients.v1.ListServiceAccessTokensRequest
-	33, // 35: clients.v1.ServiceAccessTokensService.RevokeServiceAccessToken:input_type -> clients.v1.RevokeServiceAccessTokenRequest
-	2,  // 36: clients.v1.UsersService.GetUser:output_type -> clients.v1.GetUserResponse
-	4,  // 37: clients.v1.UsersService.GetUsers:output_type -> clients.v1.GetUsersResponse
-	14, // 38: clients.v1.UsersService.CreateUser:output_type -> clients.v1.CreateUserResponse
-	6,  // 39: clients.v1.UsersService.GetUserRoles:output_type -> clients.v1.GetUserRolesResponse
-	10, // 40: clients.v1.UsersService.GetUserMetadata:output_type -> clients.v1.GetUserMetadataResponse
-	12, // 41: clients.v1.UsersService.UpdateUserMetadata:output_type -> clients.v1.UpdateUserMetadataResponse
-	17, // 42: clients.v1.SessionsService.GetSession:output_type -> clients.v1.GetSessionResponse
-	19, // 43: clients.v1.SessionsService.SignOutSession:output_type -> clients.v1.SignOutSessionResponse
-	22, // 44: clients.v1.TokensService.IntrospectToken:output_type -> clients.v1.IntrospectTokenResponse
-	25, // 45: clients.v1.RolesService.RegisterRoleResources:output_type -> clients.v1.RegisterRoleResourcesResponse
-	29, // 46: clients.v1.ServiceAccessTokensService.CreateServiceAccessToken:output_type -> clients.v1.CreateServiceAccessTokenResponse
-	32, // 47: clients.v1.ServiceAccessTokensService.ListServiceAccessTokens:output_type -> clients.v1.ListServiceAccessTokensResponse
-	34, // 48: clients.v1.ServiceAccessTokensService.RevokeServiceAccessToken:output_type -> clients.v1.RevokeServiceAccessTokenResponse
-	36, // [36:49] is the sub-list for method output_type
-	23, // [23:36] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	22, // [22:23] is the sub-list for extension extendee
+	39, // 12: clients.v1.IntrospectTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	26, // 13: clients.v1.RegisterRoleResourcesRequest.metadata:type_name -> clients.v1.RegisterRoleResourcesRequestMetadata
+	38, // 14: clients.v1.RegisterRoleResourcesRequest.resources:type_name -> clients.v1.RegisterRoleResourcesRequest.Resources
+	39, // 15: clients.v1.ServiceAccessToken.creation_time:type_name -> google.protobuf.Timestamp
+	39, // 16: clients.v1.ServiceAccessToken.expire_time:type_name -> google.protobuf.Timestamp
+	30, // 17: clients.v1.CreateServiceAccessTokenRequest.token:type_name -> clients.v1.ServiceAccessToken
+	30, // 18: clients.v1.CreateServiceAccessTokenResponse.token:type_name -> clients.v1.ServiceAccessToken
+	34, // 19: clients.v1.ListServiceAccessTokensRequest.filters:type_name -> clients.v1.ListServiceAccessTokensFilter
+	30, // 20: clients.v1.ListServiceAccessTokensResponse.tokens:type_name -> clients.v1.ServiceAccessToken
+	29, // 21: clients.v1.RegisterRoleResourcesRequest.Resources.resources:type_name -> clients.v1.RoleResource
+	41, // 22: clients.v1.sams_required_scopes:extendee -> google.protobuf.MethodOptions
+	41, // 23: clients.v1.sams_required_roles:extendee -> google.protobuf.MethodOptions
+	41, // 24: clients.v1.sams_resource_id_field:extendee -> google.protobuf.MethodOptions
+	41, // 25: clients.v1.sams_authenticated_only:extendee -> google.protobuf.MethodOptions
+	0,  // 26: clients.v1.UsersService.GetUser:input_type -> clients.v1.GetUserRequest
+	3,  // 27: clients.v1.UsersService.GetUsers:input_type -> clients.v1.GetUsersRequest
+	13, // 28: clients.v1.UsersService.CreateUser:input_type -> clients.v1.CreateUserRequest
+	5,  // 29: clients.v1.UsersService.GetUserRoles:input_type -> clients.v1.GetUserRolesRequest
+	8,  // 30: clients.v1.UsersService.GetUserMetadata:input_type -> clients.v1.GetUserMetadataRequest
+	11, // 31: clients.v1.UsersService.UpdateUserMetadata:input_type -> clients.v1.UpdateUserMetadataRequest
+	15, // 32: clients.v1.SessionsService.GetSession:input_type -> clients.v1.GetSessionRequest
+	18, // 33: clients.v1.SessionsService.SignOutSession:input_type -> clients.v1.SignOutSessionRequest
+	22, // 34: clients.v1.TokensService.IntrospectToken:input_type -> clients.v1.IntrospectTokenRequest
+	27, // 35: clients.v1.RolesService.RegisterRoleResources:input_type -> clients.v1.RegisterRoleResourcesRequest
+	24, // 36: clients.v1.RolesService.GetRoleResourcesCapabilities:input_type -> clients.v1.GetRoleResourcesCapabilitiesRequest
+	31, // 37: clients.v1.ServiceAccessTokensService.CreateServiceAccessToken:input_type -> clients.v1.CreateServiceAccessTokenRequest
+	33, // 38: clients.v1.ServiceAccessTokensService.ListServiceAccessTokens:input_type -> clients.v1.ListServiceAccessTokensRequest
+	36, // 39: clients.v1.ServiceAccessTokensService.RevokeServiceAccessToken:input_type -> clients.v1.RevokeServiceAccessTokenRequest
+	2,  // 40: clients.v1.UsersService.GetUser:output_type -> clients.v1.GetUserResponse
+	4,  // 41: clients.v1.UsersService.GetUsers:output_type -> clients.v1.GetUsersResponse
+	14, // 42: clients.v1.UsersService.CreateUser:output_type -> clients.v1.CreateUserResponse
+	6,  // 43: clients.v1.UsersService.GetUserRoles:output_type -> clients.v1.GetUserRolesResponse
+	10, // 44: clients.v1.UsersService.GetUserMetadata:output_type -> clients.v1.GetUserMetadataResponse
+	12, // 45: clients.v1.UsersService.UpdateUserMetadata:output_type -> clients.v1.UpdateUserMetadataResponse
+	17, // 46: clients.v1.SessionsService.GetSession:output_type -> clients.v1.GetSessionResponse
+	19, // 47: clients.v1.SessionsService.SignOutSession:output_type -> clients.v1.SignOutSessionResponse
+	23, // 48: clients.v1.TokensService.IntrospectToken:output_type -> clients.v1.IntrospectTokenResponse
+	28, // 49: clients.v1.RolesService.RegisterRoleResources:output_type -> clients.v1.RegisterRoleResourcesResponse
+	25, // 50: clients.v1.RolesService.GetRoleResourcesCapabilities:output_type -> clients.v1.GetRoleResourcesCapabilitiesResponse
+	32, // 51: clients.v1.ServiceAccessTokensService.CreateServiceAccessToken:output_type -> clients.v1.CreateServiceAccessTokenResponse
+	35, // 52: clients.v1.ServiceAccessTokensService.ListServiceAccessTokens:output_type -> clients.v1.ListServiceAccessTokensResponse
+	37, // 53: clients.v1.ServiceAccessTokensService.RevokeServiceAccessToken:output_type -> clients.v1.RevokeServiceAccessTokenResponse
+	40, // [40:54] is the sub-list for method output_type
+	26, // [26:40] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	22, // [22:26] is the sub-list for extension extendee
 	0,  // [0:22] is the sub-list for field type_name
 }
 
@@ -2863,7 +3187,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IntrospectTokenRequest); i {
+			switch v := v.(*ErrorRemovalThresholdExceeded); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2875,7 +3199,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IntrospectTokenResponse); i {
+			switch v := v.(*IntrospectTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2887,7 +3211,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterRoleResourcesRequestMetadata); i {
+			switch v := v.(*IntrospectTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2899,7 +3223,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterRoleResourcesRequest); i {
+			switch v := v.(*GetRoleResourcesCapabilitiesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2911,7 +3235,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterRoleResourcesResponse); i {
+			switch v := v.(*GetRoleResourcesCapabilitiesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2923,7 +3247,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoleResource); i {
+			switch v := v.(*RegisterRoleResourcesRequestMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2935,7 +3259,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServiceAccessToken); i {
+			switch v := v.(*RegisterRoleResourcesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2947,7 +3271,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateServiceAccessTokenRequest); i {
+			switch v := v.(*RegisterRoleResourcesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2959,7 +3283,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateServiceAccessTokenResponse); i {
+			switch v := v.(*RoleResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2971,7 +3295,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListServiceAccessTokensRequest); i {
+			switch v := v.(*ServiceAccessToken); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2983,7 +3307,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListServiceAccessTokensFilter); i {
+			switch v := v.(*CreateServiceAccessTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2995,7 +3319,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListServiceAccessTokensResponse); i {
+			switch v := v.(*CreateServiceAccessTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3007,7 +3331,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RevokeServiceAccessTokenRequest); i {
+			switch v := v.(*ListServiceAccessTokensRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3019,7 +3343,7 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RevokeServiceAccessTokenResponse); i {
+			switch v := v.(*ListServiceAccessTokensFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3031,6 +3355,42 @@ func file_clients_v1_clients_proto_init() {
 			}
 		}
 		file_clients_v1_clients_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListServiceAccessTokensResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clients_v1_clients_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeServiceAccessTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clients_v1_clients_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeServiceAccessTokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clients_v1_clients_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RegisterRoleResourcesRequest_Resources); i {
 			case 0:
 				return &v.state
@@ -3045,11 +3405,11 @@ func file_clients_v1_clients_proto_init() {
 	}
 	file_clients_v1_clients_proto_msgTypes[7].OneofWrappers = []interface{}{}
 	file_clients_v1_clients_proto_msgTypes[16].OneofWrappers = []interface{}{}
-	file_clients_v1_clients_proto_msgTypes[24].OneofWrappers = []interface{}{
+	file_clients_v1_clients_proto_msgTypes[27].OneofWrappers = []interface{}{
 		(*RegisterRoleResourcesRequest_Metadata)(nil),
 		(*RegisterRoleResourcesRequest_Resources_)(nil),
 	}
-	file_clients_v1_clients_proto_msgTypes[31].OneofWrappers = []interface{}{
+	file_clients_v1_clients_proto_msgTypes[34].OneofWrappers = []interface{}{
 		(*ListServiceAccessTokensFilter_Service)(nil),
 		(*ListServiceAccessTokensFilter_UserId)(nil),
 		(*ListServiceAccessTokensFilter_ShowExpired)(nil),
@@ -3060,8 +3420,8 @@ func file_clients_v1_clients_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_clients_v1_clients_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   36,
-			NumExtensions: 1,
+			NumMessages:   39,
+			NumExtensions: 4,
 			NumServices:   5,
 		},
 		GoTypes:           file_clients_v1_clients_proto_goTypes,
@@ -69,6 +69,9 @@ const (
 	// RolesServiceRegisterRoleResourcesProcedure is the fully-qualified name of the RolesService's
 	// RegisterRoleResources RPC.
 	RolesServiceRegisterRoleResourcesProcedure = "/clients.v1.RolesService/RegisterRoleResources"
+	// RolesServiceGetRoleResourcesCapabilitiesProcedure is the fully-qualified name of the
+	// RolesService's GetRoleResourcesCapabilities RPC.
+	RolesServiceGetRoleResourcesCapabilitiesProcedure = "/clients.v1.RolesService/GetRoleResourcesCapabilities"
 	// ServiceAccessTokensServiceCreateServiceAccessTokenProcedure is the fully-qualified name of the
 	// ServiceAccessTokensService's CreateServiceAccessToken RPC.
 	ServiceAccessTokensServiceCreateServiceAccessTokenProcedure = "/clients.v1.ServiceAccessTokensService/CreateServiceAccessToken"
@@ -96,6 +99,7 @@ var (
 	tokensServiceIntrospectTokenMethodDescriptor                       = tokensServiceServiceDescriptor.Methods().ByName("IntrospectToken")
 	rolesServiceServiceDescriptor                                      = v1.File_clients_v1_clients_proto.Services().ByName("RolesService")
 	rolesServiceRegisterRoleResourcesMethodDescriptor                  = rolesServiceServiceDescriptor.Methods().ByName("RegisterRoleResources")
+	rolesServiceGetRoleResourcesCapabilitiesMethodDescriptor           = rolesServiceServiceDescriptor.Methods().ByName("GetRoleResourcesCapabilities")
 	serviceAccessTokensServiceServiceDescriptor                        = v1.File_clients_v1_clients_proto.Services().ByName("ServiceAccessTokensService")
 	serviceAccessTokensServiceCreateServiceAccessTokenMethodDescriptor = serviceAccessTokensServiceServiceDescriptor.Methods().ByName("CreateServiceAccessToken")
 	serviceAccessTokensServiceListServiceAccessTokensMethodDescriptor  = serviceAccessTokensServiceServiceDescriptor.Methods().ByName("ListServiceAccessTokens")
@@ -552,6 +556,11 @@ type RolesServiceClient interface {
 	// If another client is currently registering resources for the same resource type
 	// this request will return an Aborted error.
 	RegisterRoleResources(context.Context) *connect.ClientStreamForClient[v1.RegisterRoleResourcesRequest, v1.RegisterRoleResourcesResponse]
+	// GetRoleResourcesCapabilities returns the optional RegisterRoleResources
+	// features supported by SAMS. Clients MUST check it before relying on any of
+	// them, as older versions of SAMS silently ignore unknown fields and commit
+	// the registration.
+	GetRoleResourcesCapabilities(context.Context, *connect.Request[v1.GetRoleResourcesCapabilitiesRequest]) (*connect.Response[v1.GetRoleResourcesCapabilitiesResponse], error)
 }
 
 // NewRolesServiceClient constructs a client for the clients.v1.RolesService service. By default, it
@@ -570,12 +579,20 @@ func NewRolesServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(rolesServiceRegisterRoleResourcesMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getRoleResourcesCapabilities: connect.NewClient[v1.GetRoleResourcesCapabilitiesRequest, v1.GetRoleResourcesCapabilitiesResponse](
+			httpClient,
+			baseURL+RolesServiceGetRoleResourcesCapabilitiesProcedure,
+			connect.WithSchema(rolesServiceGetRoleResourcesCapabilitiesMethodDescriptor),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // rolesServiceClient implements RolesServiceClient.
 type rolesServiceClient struct {
-	registerRoleResources *connect.Client[v1.RegisterRoleResourcesRequest, v1.RegisterRoleResourcesResponse]
+	registerRoleResources        *connect.Client[v1.RegisterRoleResourcesRequest, v1.RegisterRoleResourcesResponse]
+	getRoleResourcesCapabilities *connect.Client[v1.GetRoleResourcesCapabilitiesRequest, v1.GetRoleResourcesCapabilitiesResponse]
 }
 
 // RegisterRoleResources calls clients.v1.RolesService.RegisterRoleResources.
@@ -583,6 +600,11 @@ func (c *rolesServiceClient) RegisterRoleResources(ctx context.Context) *connect
 	return c.registerRoleResources.CallClientStream(ctx)
 }
 
+// GetRoleResourcesCapabilities calls clients.v1.RolesService.GetRoleResourcesCapabilities.
+func (c *rolesServiceClient) GetRoleResourcesCapabilities(ctx context.Context, req *connect.Request[v1.GetRoleResourcesCapabilitiesRequest]) (*connect.Response[v1.GetRoleResourcesCapabilitiesResponse], error) {
+	return c.getRoleResourcesCapabilities.CallUnary(ctx, req)
+}
+
 // RolesServiceHandler is an implementation of the clients.v1.RolesService service.
 type RolesServiceHandler interface {
 	// RegisterRoleResources registers resources with SAMS.
@@ -591,6 +613,11 @@ type RolesServiceHandler interface {
 	// If another client is currently registering resources for the same resource type
 	// this request will return an Aborted error.
 	RegisterRoleResources(context.Context, *connect.ClientStream[v1.RegisterRoleResourcesRequest]) (*connect.Response[v1.RegisterRoleResourcesResponse], error)
+	// GetRoleResourcesCapabilities returns the optional RegisterRoleResources
+	// features supported by SAMS. Clients MUST check it before relying on any of
+	// them, as older versions of SAMS silently ignore unknown fields and commit
+	// the registration.
+	GetRoleResourcesCapabilities(context.Context, *connect.Request[v1.GetRoleResourcesCapabilitiesRequest]) (*connect.Response[v1.GetRoleResourcesCapabilitiesResponse], error)
 }
 
 // NewRolesServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -605,10 +632,19 @@ func NewRolesServiceHandler(svc RolesServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(rolesServiceRegisterRoleResourcesMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	rolesServiceGetRoleResourcesCapabilitiesHandler := connect.NewUnaryHandler(
+		RolesServiceGetRoleResourcesCapabilitiesProcedure,
+		svc.GetRoleResourcesCapabilities,
+		connect.WithSchema(rolesServiceGetRoleResourcesCapabilitiesMethodDescriptor),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/clients.v1.RolesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RolesServiceRegisterRoleResourcesProcedure:
 			rolesServiceRegisterRoleResourcesHandler.ServeHTTP(w, r)
+		case RolesServiceGetRoleResourcesCapabilitiesProcedure:
+			rolesServiceGetRoleResourcesCapabilitiesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -622,6 +658,10 @@ func (UnimplementedRolesServiceHandler) RegisterRoleResources(context.Context, *
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("clients.v1.RolesService.RegisterRoleResources is not implemented"))
 }
 
+func (UnimplementedRolesServiceHandler) GetRoleResourcesCapabilities(context.Context, *connect.Request[v1.GetRoleResourcesCapabilitiesRequest]) (*connect.Response[v1.GetRoleResourcesCapabilitiesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("clients.v1.RolesService.GetRoleResourcesCapabilities is not implemented"))
+}
+
 // ServiceAccessTokensServiceClient is a client for the clients.v1.ServiceAccessTokensService
 // service.
 type ServiceAccessTokensServiceClient interface {
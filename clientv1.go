@@ -8,7 +8,6 @@ import (
 
 	"connectrpc.com/connect"
 	"connectrpc.com/otelconnect"
-	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
@@ -23,7 +22,14 @@ var (
 	sessionsCacheExpiry = 3 * time.Second
 	// Most client credential tokens expire on >1 hour intervals, so 30 seconds
 	// should be reasonable.
-	introspectTokenCacheExpiry = 30 * time.Second
+	defaultIntrospectTokenCacheTTL = 30 * time.Second
+	// Inactive tokens are usually retried by misconfigured clients in quick
+	// succession, but a token can't become active again, so only briefly
+	// remember them to avoid being a thundering herd on SAMS.
+	defaultIntrospectTokenInactiveCacheTTL = 5 * time.Second
+	// Role assignments change rarely, and entries are evicted upon
+	// "UserRolesUpdated" notifications when ClientV1.WithCacheInvalidation is used.
+	userRolesCacheExpiry = 30 * time.Second
 )
 
 // ClientV1 provides helpers to talk to a SAMS instance via Clients API v1.
@@ -32,9 +38,11 @@ type ClientV1 struct {
 	tokenSource oauth2.TokenSource
 
 	// sessionsCache may be nil if not enabled.
-	sessionsCache *expirable.LRU[string, *clientsv1.Session]
+	sessionsCache *evictableLRU[string, *clientsv1.Session]
 	// introspectTokenCache may be nil if not enabled.
-	introspectTokenCache *expirable.LRU[string, *IntrospectTokenResponse]
+	introspectTokenCache *tokenIntrospectionCache
+	// userRolesCache may be nil if not enabled.
+	userRolesCache *evictableLRU[userRolesCacheKey, []*clientsv1.Role]
 
 	// defaultInterceptors is a list of default interceptors to use with all
 	// clients, generally providing enhanced diagnostics.
@@ -59,6 +67,23 @@ type ClientV1Config struct {
 	//
 	// The default of 0 (or less) disables caching.
 	IntrospectTokenCacheSize int
+	// IntrospectTokenCacheTTL is the maximum duration to cache the result of an
+	// active token. Results are never cached beyond the token's expiry, and
+	// tokens without an expiry are cached for exactly this duration.
+	//
+	// The default of 0 (or less) uses 30 seconds.
+	IntrospectTokenCacheTTL time.Duration
+	// IntrospectTokenInactiveCacheTTL is the duration to cache the result of an
+	// inactive token.
+	//
+	// The default of 0 uses 5 seconds, and a negative value disables caching of
+	// inactive tokens.
+	IntrospectTokenInactiveCacheTTL time.Duration
+	// UserRolesCacheSize is the number of per-service user role lookups to cache
+	// in memory.
+	//
+	// The default of 0 (or less) disables caching.
+	UserRolesCacheSize int
 }
 
 func (c ClientV1Config) Validate() error {
@@ -90,21 +115,25 @@ func NewClientV1(config ClientV1Config) (*ClientV1, error) {
 
 	apiURL := config.getAPIURL()
 
-	var sessionsCache *expirable.LRU[string, *clientsv1.Session]
+	var sessionsCache *evictableLRU[string, *clientsv1.Session]
 	if config.SessionsCacheSize > 0 {
-		sessionsCache = expirable.NewLRU[string, *clientsv1.Session](
-			config.SessionsCacheSize,
-			nil, // no eviction callback needed
-			sessionsCacheExpiry,
-		)
+		sessionsCache = newEvictableLRU[string, *clientsv1.Session](config.SessionsCacheSize, sessionsCacheExpiry)
 	}
-	var introspectTokenCache *expirable.LRU[string, *IntrospectTokenResponse]
+	var introspectTokenCache *tokenIntrospectionCache
 	if config.IntrospectTokenCacheSize > 0 {
-		introspectTokenCache = expirable.NewLRU[string, *IntrospectTokenResponse](
-			config.IntrospectTokenCacheSize,
-			nil, // no eviction callback needed
-			introspectTokenCacheExpiry,
-		)
+		ttl := config.IntrospectTokenCacheTTL
+		if ttl <= 0 {
+			ttl = defaultIntrospectTokenCacheTTL
+		}
+		inactiveTTL := config.IntrospectTokenInactiveCacheTTL
+		if inactiveTTL == 0 {
+			inactiveTTL = defaultIntrospectTokenInactiveCacheTTL
+		}
+		introspectTokenCache = newTokenIntrospectionCache(config.IntrospectTokenCacheSize, ttl, inactiveTTL)
+	}
+	var userRolesCache *evictableLRU[userRolesCacheKey, []*clientsv1.Role]
+	if config.UserRolesCacheSize > 0 {
+		userRolesCache = newEvictableLRU[userRolesCacheKey, []*clientsv1.Role](config.UserRolesCacheSize, userRolesCacheExpiry)
 	}
 
 	return &ClientV1{
@@ -113,6 +142,7 @@ func NewClientV1(config ClientV1Config) (*ClientV1, error) {
 		defaultInterceptors:  []connect.Interceptor{otelinterceptor},
 		sessionsCache:        sessionsCache,
 		introspectTokenCache: introspectTokenCache,
+		userRolesCache:       userRolesCache,
 	}, nil
 }
 
@@ -142,9 +172,13 @@ func parseResponseAndError[T any](resp *connect.Response[T], err error) (*connec
 			return nil, errors.Wrap(err, "extract error detail value")
 		}
 
-		switch value.(type) {
+		switch v := value.(type) {
 		case *clientsv1.ErrorRecordMismatch:
 			return nil, ErrRecordMismatch
+		case *clientsv1.ErrorRemovalThresholdExceeded:
+			return nil, errors.Wrapf(ErrRemovalThresholdExceeded,
+				"%d of %d existing resources would be removed",
+				v.GetRemovedResourceCount(), v.GetExistingResourceCount())
 		}
 	}
 
@@ -158,7 +192,7 @@ func (c *ClientV1) gRPCURL() string {
 
 // Users returns a client handler to interact with the UsersServiceV1 API.
 func (c *ClientV1) Users() *UsersServiceV1 {
-	return &UsersServiceV1{client: c}
+	return &UsersServiceV1{client: c, userRolesCache: c.userRolesCache}
 }
 
 // Sessions returns a client handler to interact with the SessionsServiceV1 API.
@@ -187,6 +221,14 @@ var (
 	// e.g. Two clients trying to perform an operation at the same time for the same resource.
 	// It is safe to retry the request at a later time.
 	ErrAborted = errors.New("aborted")
+	// ErrRemovalThresholdExceeded is returned when registering role resources
+	// would remove more than the configured percentage of existing resources, see
+	// RegisterRoleResourcesOptions.MaxRemovalPercent. No changes were committed.
+	ErrRemovalThresholdExceeded = errors.New("removal threshold exceeded")
+	// ErrUnsupported is returned when SAMS does not support a requested feature,
+	// e.g. RolesServiceV1.DiffRoleResources on an older version of SAMS. No
+	// changes were committed.
+	ErrUnsupported = errors.New("unsupported by SAMS")
 )
 
 // ClientCredentialsTokenSource returns a TokenSource that generates an access
@@ -198,10 +240,10 @@ var (
 // source. Scopes should be defined using the available scopes package. All
 // requested scopes must be allowed by the registered client - see:
 // https://sourcegraph.notion.site/6cc4a1bd9cb247eea9674dbf9d5ce8c3
-func ClientCredentialsTokenSource(conn ConnConfig, clientID, clientSecret string, requestScopes []scopes.Scope) oauth2.TokenSource {
+func ClientCredentialsTokenSource(conn ConnConfig, clientID string, clientSecret Secret, requestScopes []scopes.Scope) oauth2.TokenSource {
 	config := clientcredentials.Config{
 		ClientID:     clientID,
-		ClientSecret: clientSecret,
+		ClientSecret: clientSecret.Reveal(),
 		TokenURL:     fmt.Sprintf("%s/oauth/token", conn.getAPIURL()),
 		Scopes:       scopes.ToStrings(requestScopes),
 	}
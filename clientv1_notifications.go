@@ -0,0 +1,121 @@
+package sams
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	notificationsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/notifications/v1"
+)
+
+// WithCacheInvalidation returns a copy of the given SAMS notification handlers
+// that evicts the in-memory cache entries of this client affected by each
+// notification, before calling the original handler (if any):
+//
+//   - "SessionInvalidated" evicts the cached session.
+//   - "UserDeleted" evicts all cached sessions, token introspection results and
+//     roles of the user.
+//   - "UserRolesUpdated" evicts the cached roles of the user for the service.
+//
+// The returned handlers should be used in notificationsv1.SubscriberOptions.
+func (c *ClientV1) WithCacheInvalidation(handlers notificationsv1.SubscriberHandlers) notificationsv1.SubscriberHandlers {
+	onSessionInvalidated := handlers.OnSessionInvalidated
+	handlers.OnSessionInvalidated = func(ctx context.Context, data *notificationsv1.SessionInvalidatedData) error {
+		c.evictSession(data.SessionID)
+		if onSessionInvalidated == nil {
+			return nil
+		}
+		return onSessionInvalidated(ctx, data)
+	}
+
+	onUserDeleted := handlers.OnUserDeleted
+	handlers.OnUserDeleted = func(ctx context.Context, data *notificationsv1.UserDeletedData) error {
+		c.evictUser(data.AccountID)
+		if onUserDeleted == nil {
+			return nil
+		}
+		return onUserDeleted(ctx, data)
+	}
+
+	onUserRolesUpdated := handlers.OnUserRolesUpdated
+	handlers.OnUserRolesUpdated = func(ctx context.Context, data *notificationsv1.UserRolesUpdatedData) error {
+		c.evictUserRoles(data.AccountID, string(data.Service))
+		if onUserRolesUpdated == nil {
+			return nil
+		}
+		return onUserRolesUpdated(ctx, data)
+	}
+	return handlers
+}
+
+// evictSession removes the session with the given ID from the sessions cache.
+func (c *ClientV1) evictSession(sessionID string) {
+	if c.sessionsCache != nil {
+		_ = c.sessionsCache.Remove(sessionID)
+	}
+}
+
+// evictUser removes all entries that belong to the user with the given ID from
+// all caches.
+func (c *ClientV1) evictUser(userID string) {
+	if c.sessionsCache != nil {
+		c.sessionsCache.removeFunc(func(_ string, session *clientsv1.Session) bool {
+			return session.GetUser().GetId() == userID
+		})
+	}
+	if c.introspectTokenCache != nil {
+		c.introspectTokenCache.removeUser(userID)
+	}
+	if c.userRolesCache != nil {
+		c.userRolesCache.removeFunc(func(key userRolesCacheKey, _ []*clientsv1.Role) bool {
+			return key.userID == userID
+		})
+	}
+}
+
+// evictUserRoles removes the roles of the user with the given ID scoped by the
+// service from the user roles cache.
+func (c *ClientV1) evictUserRoles(userID, service string) {
+	if c.userRolesCache != nil {
+		_ = c.userRolesCache.Remove(userRolesCacheKey{userID: userID, service: service})
+	}
+}
+
+// evictableLRU is an expirable.LRU that supports removing entries based on
+// their values, without removing entries that are concurrently replaced.
+type evictableLRU[K comparable, V any] struct {
+	*expirable.LRU[K, V]
+	// mu serializes Add and removeFunc, so that the value matched by removeFunc
+	// is the value it removes.
+	mu sync.Mutex
+}
+
+func newEvictableLRU[K comparable, V any](size int, ttl time.Duration) *evictableLRU[K, V] {
+	return &evictableLRU[K, V]{
+		LRU: expirable.NewLRU[K, V](
+			size,
+			nil, // no eviction callback needed
+			ttl,
+		),
+	}
+}
+
+// Add adds a value to the cache, returns true if an eviction occurred.
+func (c *evictableLRU[K, V]) Add(key K, value V) (evicted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.LRU.Add(key, value)
+}
+
+// removeFunc removes all entries for which match returns true.
+func (c *evictableLRU[K, V]) removeFunc(match func(key K, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range c.LRU.Keys() {
+		if value, ok := c.LRU.Peek(key); ok && match(key, value) {
+			_ = c.LRU.Remove(key)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package sams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	notificationsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/notifications/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+func TestClientV1WithCacheInvalidation(t *testing.T) {
+	newClient := func(t *testing.T) *ClientV1 {
+		conn := ConnConfig{ExternalURL: "https://accounts.sourcegraph.com"}
+		c, err := NewClientV1(ClientV1Config{
+			ConnConfig:               conn,
+			TokenSource:              ClientCredentialsTokenSource(conn, "fooclient", "barsecret", []scopes.Scope{scopes.Profile}),
+			SessionsCacheSize:        10,
+			IntrospectTokenCacheSize: 10,
+			UserRolesCacheSize:       10,
+		})
+		require.NoError(t, err)
+
+		c.sessionsCache.Add("alice-session", &clientsv1.Session{Id: "alice-session", User: &clientsv1.User{Id: "alice"}})
+		c.sessionsCache.Add("bob-session", &clientsv1.Session{Id: "bob-session", User: &clientsv1.User{Id: "bob"}})
+		c.introspectTokenCache.add("alice-token", &IntrospectTokenResponse{Active: true, UserID: "alice", ExpiresAt: time.Now().Add(time.Hour)})
+		c.introspectTokenCache.add("client-token", &IntrospectTokenResponse{Active: true, ExpiresAt: time.Now().Add(time.Hour)})
+		c.userRolesCache.Add(userRolesCacheKey{userID: "alice", service: string(services.Dotcom)}, nil)
+		c.userRolesCache.Add(userRolesCacheKey{userID: "alice", service: string(services.SSC)}, nil)
+		c.userRolesCache.Add(userRolesCacheKey{userID: "bob", service: string(services.Dotcom)}, nil)
+		return c
+	}
+
+	t.Run("OnSessionInvalidated", func(t *testing.T) {
+		c := newClient(t)
+		var called bool
+		handlers := c.WithCacheInvalidation(notificationsv1.SubscriberHandlers{
+			OnSessionInvalidated: func(context.Context, *notificationsv1.SessionInvalidatedData) error {
+				called = true
+				return nil
+			},
+		})
+		err := handlers.OnSessionInvalidated(context.Background(), &notificationsv1.SessionInvalidatedData{
+			AccountID: "alice",
+			SessionID: "alice-session",
+		})
+		require.NoError(t, err)
+		assert.True(t, called)
+		assert.ElementsMatch(t, []string{"bob-session"}, c.sessionsCache.Keys())
+	})
+
+	t.Run("OnUserDeleted", func(t *testing.T) {
+		c := newClient(t)
+		// Handlers not provided by the caller are populated to still evict.
+		handlers := c.WithCacheInvalidation(notificationsv1.SubscriberHandlers{})
+		err := handlers.OnUserDeleted(context.Background(), &notificationsv1.UserDeletedData{AccountID: "alice"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"bob-session"}, c.sessionsCache.Keys())
+		_, ok := c.introspectTokenCache.get("alice-token")
+		assert.False(t, ok)
+		_, ok = c.introspectTokenCache.get("client-token")
+		assert.True(t, ok)
+		assert.ElementsMatch(t,
+			[]userRolesCacheKey{{userID: "bob", service: string(services.Dotcom)}},
+			c.userRolesCache.Keys())
+	})
+
+	t.Run("OnUserRolesUpdated", func(t *testing.T) {
+		c := newClient(t)
+		handlers := c.WithCacheInvalidation(notificationsv1.SubscriberHandlers{})
+		err := handlers.OnUserRolesUpdated(context.Background(), &notificationsv1.UserRolesUpdatedData{
+			AccountID: "alice",
+			Service:   services.Dotcom,
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t,
+			[]userRolesCacheKey{
+				{userID: "alice", service: string(services.SSC)},
+				{userID: "bob", service: string(services.Dotcom)},
+			},
+			c.userRolesCache.Keys())
+	})
+
+	t.Run("caches disabled", func(t *testing.T) {
+		conn := ConnConfig{ExternalURL: "https://accounts.sourcegraph.com"}
+		c, err := NewClientV1(ClientV1Config{
+			ConnConfig:  conn,
+			TokenSource: ClientCredentialsTokenSource(conn, "fooclient", "barsecret", []scopes.Scope{scopes.Profile}),
+		})
+		require.NoError(t, err)
+		handlers := c.WithCacheInvalidation(notificationsv1.SubscriberHandlers{})
+		assert.NoError(t, handlers.OnUserDeleted(context.Background(), &notificationsv1.UserDeletedData{AccountID: "alice"}))
+	})
+}
+
+func TestEvictableLRURemoveFunc(t *testing.T) {
+	c := newEvictableLRU[string, string](10, time.Minute)
+	c.Add("session", "alice")
+
+	// The entry is replaced concurrently after it was matched, e.g. because the
+	// session was fetched again for another user.
+	added := make(chan struct{})
+	c.removeFunc(func(_ string, userID string) bool {
+		go func() {
+			defer close(added)
+			c.Add("session", "bob")
+		}()
+		time.Sleep(10 * time.Millisecond)
+		return userID == "alice"
+	})
+	<-added
+
+	userID, ok := c.Get("session")
+	require.True(t, ok, "replaced entry was removed")
+	assert.Equal(t, "bob", userID)
+}
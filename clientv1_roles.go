@@ -3,10 +3,15 @@ package sams
 import (
 	"context"
 	"io"
+	"iter"
 	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"connectrpc.com/connect"
 	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/google/uuid"
 	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
@@ -50,71 +55,343 @@ func (r RegisterResourcesMetadata) validate() error {
 //
 // Required scope: sams::roles.resources::write
 func (s *RolesServiceV1) RegisterRoleResources(ctx context.Context, metadata RegisterResourcesMetadata, resourcesIterator func() ([]*clientsv1.RoleResource, error)) (uint64, error) {
+	resp, err := s.registerRoleResources(ctx, metadata, false, 0, func(send func([]*clientsv1.RoleResource) error) error {
+		for {
+			resources, err := resourcesIterator()
+			if err != nil {
+				return errors.Wrap(err, "failed to get resources")
+			}
+			if len(resources) == 0 {
+				return nil
+			}
+			if err = send(resources); err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		// Stream closed due to another replica registering the same resources.
+		if errors.Is(err, ErrAborted) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return resp.GetResourceCount(), nil
+}
+
+const (
+	defaultRegisterRoleResourcesMaxBatchCount = 1000
+	// Stay well under the default gRPC message size limit of 4 MiB.
+	defaultRegisterRoleResourcesMaxBatchBytes = 1 << 20
+)
+
+// RegisterRoleResourcesOptions configures RegisterRoleResourcesSeq.
+type RegisterRoleResourcesOptions struct {
+	// MaxBatchCount is the maximum number of resources to send in a single
+	// message of the stream.
+	//
+	// The default of 0 (or less) uses 1000.
+	MaxBatchCount int
+	// MaxBatchBytes is the maximum encoded size in bytes of the resources sent in
+	// a single message of the stream.
+	//
+	// The default of 0 (or less) uses 1 MiB.
+	MaxBatchBytes int
+	// OnProgress, if set, is called after each batch of resources is sent.
+	OnProgress func(progress RegisterRoleResourcesProgress)
+	// MaxRemovalPercent, if greater than zero, makes SAMS refuse to commit the
+	// registration when more than this percentage (1-100) of the existing
+	// resources would be removed, and ErrRemovalThresholdExceeded is returned.
+	// This guards against a faulty resources provider wiping out all resource
+	// role assignments, e.g. by returning an empty list.
+	//
+	// SAMS is checked for support before any resource is sent, and
+	// ErrUnsupported is returned if it would ignore the threshold.
+	//
+	// The default of 0 disables the check.
+	MaxRemovalPercent int
+}
+
+func (opts RegisterRoleResourcesOptions) validate() error {
+	if opts.MaxRemovalPercent < 0 || opts.MaxRemovalPercent > 100 {
+		return errors.Newf("MaxRemovalPercent must be between 0 and 100, got %d", opts.MaxRemovalPercent)
+	}
+	return nil
+}
+
+// RegisterRoleResourcesProgress is the cumulative progress of a
+// RegisterRoleResourcesSeq call.
+type RegisterRoleResourcesProgress struct {
+	// Batches is the number of batches sent so far.
+	Batches int
+	// Resources is the number of resources sent so far.
+	Resources int
+	// Bytes is the encoded size of the resources sent so far.
+	Bytes int
+}
+
+// RegisterRoleResourcesSeq is like RegisterRoleResources, but takes an iterator
+// of resources and takes care of batching them by both count and encoded size,
+// see RegisterRoleResourcesOptions. Use RoleResourcesFromChan to register
+// resources produced on a channel.
+//
+// Resources are validated as they are streamed. On the first invalid resource
+// or iterator error, the stream is cancelled instead of being completed, which
+// SAMS treats as an incomplete registration and does not commit. Earlier
+// batches may have already been sent to SAMS by then.
+//
+// Unlike RegisterRoleResources, ErrAborted is returned as-is if another replica
+// is already registering resources for the same resource type.
+//
+// Required scope: sams::roles.resources::write
+func (s *RolesServiceV1) RegisterRoleResourcesSeq(ctx context.Context, metadata RegisterResourcesMetadata, resources iter.Seq2[*clientsv1.RoleResource, error], opts RegisterRoleResourcesOptions) (uint64, error) {
+	resp, err := s.registerRoleResourcesSeq(ctx, metadata, false, resources, opts)
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetResourceCount(), nil
+}
+
+// RoleResourcesDiff is the set of changes a registration of role resources
+// would make, see RolesServiceV1.DiffRoleResources.
+type RoleResourcesDiff struct {
+	// ResourceCount is the number of resources in the registration.
+	ResourceCount uint64
+	// Added is the list of IDs of resources that would be added.
+	Added []string
+	// Removed is the list of IDs of existing resources that would be removed.
+	Removed []string
+}
+
+// DiffRoleResources is a dry run of RegisterRoleResourcesSeq: it reports which
+// resources would be added and removed by registering the given resources,
+// without committing any change. ErrRemovalThresholdExceeded is returned if
+// opts.MaxRemovalPercent would be exceeded.
+//
+// SAMS is checked for support of dry runs before any resource is sent, and
+// ErrUnsupported is returned if it would commit the registration instead.
+//
+// Required scope: sams::roles.resources::write
+func (s *RolesServiceV1) DiffRoleResources(ctx context.Context, metadata RegisterResourcesMetadata, resources iter.Seq2[*clientsv1.RoleResource, error], opts RegisterRoleResourcesOptions) (*RoleResourcesDiff, error) {
+	resp, err := s.registerRoleResourcesSeq(ctx, metadata, true, resources, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.GetDryRun() {
+		// Capabilities have been checked upfront, so this should never happen, but
+		// be loud if it does.
+		return nil, errors.New("SAMS did not perform a dry run, resources have been registered")
+	}
+	return &RoleResourcesDiff{
+		ResourceCount: resp.GetResourceCount(),
+		Added:         resp.GetAddedResourceIds(),
+		Removed:       resp.GetRemovedResourceIds(),
+	}, nil
+}
+
+func (s *RolesServiceV1) registerRoleResourcesSeq(ctx context.Context, metadata RegisterResourcesMetadata, dryRun bool, resources iter.Seq2[*clientsv1.RoleResource, error], opts RegisterRoleResourcesOptions) (*clientsv1.RegisterRoleResourcesResponse, error) {
+	if err := opts.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid options")
+	}
+	if opts.MaxBatchCount <= 0 {
+		opts.MaxBatchCount = defaultRegisterRoleResourcesMaxBatchCount
+	}
+	if opts.MaxBatchBytes <= 0 {
+		opts.MaxBatchBytes = defaultRegisterRoleResourcesMaxBatchBytes
+	}
+	if dryRun || opts.MaxRemovalPercent > 0 {
+		if err := s.checkCapabilities(ctx, dryRun, opts.MaxRemovalPercent > 0); err != nil {
+			return nil, err
+		}
+	}
+	return s.registerRoleResources(ctx, metadata, dryRun, uint32(opts.MaxRemovalPercent), func(send func([]*clientsv1.RoleResource) error) error {
+		return batchRoleResources(resources, opts, send)
+	})
+}
+
+// checkCapabilities returns ErrUnsupported if SAMS does not support the
+// requested RegisterRoleResources features, which older versions of SAMS
+// silently ignore while committing the registration.
+func (s *RolesServiceV1) checkCapabilities(ctx context.Context, dryRun, maxRemovalPercent bool) error {
+	client := s.newClient(ctx)
+	resp, err := parseResponseAndError(client.GetRoleResourcesCapabilities(ctx, connect.NewRequest(&clientsv1.GetRoleResourcesCapabilitiesRequest{})))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnimplemented {
+			return errors.Wrap(ErrUnsupported, "get role resources capabilities")
+		}
+		return errors.Wrap(err, "get role resources capabilities")
+	}
+	if dryRun && !resp.Msg.GetDryRun() {
+		return errors.Wrap(ErrUnsupported, "dry run")
+	}
+	if maxRemovalPercent && !resp.Msg.GetMaxRemovalPercent() {
+		return errors.Wrap(ErrUnsupported, "MaxRemovalPercent")
+	}
+	return nil
+}
+
+// RoleResourcesFromChan returns an iterator of the resources received from the
+// channel until it is closed, for use with RegisterRoleResourcesSeq. The
+// iterator yields ctx.Err() if the context is done before the channel is
+// closed.
+func RoleResourcesFromChan(ctx context.Context, ch <-chan *clientsv1.RoleResource) iter.Seq2[*clientsv1.RoleResource, error] {
+	return func(yield func(*clientsv1.RoleResource, error) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			case resource, ok := <-ch:
+				if !ok || !yield(resource, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// errStreamClosed is returned by the send function of registerRoleResources
+// when the server has closed the stream, e.g. due to ErrAborted.
+var errStreamClosed = errors.New("stream closed")
+
+// registerRoleResources is the shared implementation of RegisterRoleResources,
+// RegisterRoleResourcesSeq and DiffRoleResources. It opens the stream and calls
+// produce with a function to send a batch of resources, then returns the result
+// of the stream.
+func (s *RolesServiceV1) registerRoleResources(ctx context.Context, metadata RegisterResourcesMetadata, dryRun bool, maxRemovalPercent uint32, produce func(send func([]*clientsv1.RoleResource) error) error) (*clientsv1.RegisterRoleResourcesResponse, error) {
 	err := metadata.validate()
 	if err != nil {
-		return 0, errors.Wrap(err, "invalid metadata")
+		return nil, errors.Wrap(err, "invalid metadata")
 	}
 
 	/// Generate a new revision for the request metadata.
 	revision, err := uuid.NewV7()
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to generate revision for request metadata")
+		return nil, errors.Wrap(err, "failed to generate revision for request metadata")
 	}
 
+	// Returning without closing the stream cancels it, so that resources sent
+	// before an error are not registered as the complete set.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	client := s.newClient(ctx)
 	stream := client.RegisterRoleResources(ctx)
 	// Metadata must be submitted first in the stream.
 	err = stream.Send(&clientsv1.RegisterRoleResourcesRequest{
 		Payload: &clientsv1.RegisterRoleResourcesRequest_Metadata{
 			Metadata: &clientsv1.RegisterRoleResourcesRequestMetadata{
-				ResourceType: string(metadata.ResourceType),
-				Revision:     revision.String(),
+				ResourceType:      string(metadata.ResourceType),
+				Revision:          revision.String(),
+				DryRun:            dryRun,
+				MaxRemovalPercent: maxRemovalPercent,
 			},
 		},
 	})
-
-	sendResources := true
 	if err != nil {
 		// The stream has been closed; skip sending resources.
-		if errors.Is(err, io.EOF) {
-			sendResources = false
-		} else {
-			return 0, errors.Wrap(err, "failed to send metadata")
-		}
-	}
-	for sendResources {
-		resources, err := resourcesIterator()
-		if err != nil {
-			return 0, errors.Wrap(err, "failed to get resources")
-		}
-		if len(resources) == 0 {
-			sendResources = false
-			continue
+		if !errors.Is(err, io.EOF) {
+			return nil, errors.Wrap(err, "failed to send metadata")
 		}
-		err = stream.Send(&clientsv1.RegisterRoleResourcesRequest{
-			Payload: &clientsv1.RegisterRoleResourcesRequest_Resources_{
-				Resources: &clientsv1.RegisterRoleResourcesRequest_Resources{
-					Resources: resources,
+	} else {
+		err = produce(func(resources []*clientsv1.RoleResource) error {
+			err := stream.Send(&clientsv1.RegisterRoleResourcesRequest{
+				Payload: &clientsv1.RegisterRoleResourcesRequest_Resources_{
+					Resources: &clientsv1.RegisterRoleResourcesRequest_Resources{
+						Resources: resources,
+					},
 				},
-			},
-		})
-		if err != nil {
-			// The stream has been closed, so we stop sending resources.
-			if errors.Is(err, io.EOF) {
-				sendResources = false
-				continue
+			})
+			if err != nil {
+				// The stream has been closed, so we stop sending resources.
+				if errors.Is(err, io.EOF) {
+					return errStreamClosed
+				}
+				return errors.Wrap(err, "failed to send resources")
 			}
-			return 0, errors.Wrap(err, "failed to send resources")
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStreamClosed) {
+			return nil, err
 		}
 	}
 
 	resp, err := parseResponseAndError(stream.CloseAndReceive())
 	if err != nil {
-		// Stream closed due to another replica registering the same resources.
-		if errors.Is(err, ErrAborted) {
-			return 0, nil
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// batchRoleResources validates the resources and sends them in batches that
+// respect the limits of the options.
+func batchRoleResources(resources iter.Seq2[*clientsv1.RoleResource, error], opts RegisterRoleResourcesOptions, send func([]*clientsv1.RoleResource) error) error {
+	var (
+		batch      []*clientsv1.RoleResource
+		batchBytes int
+		progress   RegisterRoleResourcesProgress
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
 		}
-		return 0, err
+		if err := send(batch); err != nil {
+			return err
+		}
+		progress.Batches++
+		progress.Resources += len(batch)
+		progress.Bytes += batchBytes
+		if opts.OnProgress != nil {
+			opts.OnProgress(progress)
+		}
+		batch, batchBytes = nil, 0
+		return nil
+	}
+
+	for resource, err := range resources {
+		if err != nil {
+			return errors.Wrap(err, "failed to get resources")
+		}
+		if err = validateRoleResource(resource); err != nil {
+			return errors.Wrapf(err, "invalid resource at index %d", progress.Resources+len(batch))
+		}
+
+		// The encoded size of the resource as an element of the repeated field.
+		size := protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(resource))
+		if size > opts.MaxBatchBytes {
+			return errors.Newf("resource %q exceeds the maximum batch size of %d bytes", resource.GetResourceId(), opts.MaxBatchBytes)
+		}
+		if len(batch) >= opts.MaxBatchCount || batchBytes+size > opts.MaxBatchBytes {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+		batch = append(batch, resource)
+		batchBytes += size
+	}
+	return flush()
+}
+
+func validateRoleResource(resource *clientsv1.RoleResource) error {
+	if resource == nil {
+		return errors.New("resource cannot be nil")
 	}
-	return resp.Msg.GetResourceCount(), nil
+	id := resource.GetResourceId()
+	if id == "" {
+		return errors.New("resource ID is required")
+	}
+	if !utf8.ValidString(id) {
+		return errors.Newf("resource ID %q is not valid UTF-8", id)
+	}
+	if strings.TrimSpace(id) != id {
+		return errors.Newf("resource ID %q has leading or trailing whitespace", id)
+	}
+	name := resource.GetDisplayName()
+	if strings.TrimSpace(name) == "" {
+		return errors.Newf("display name of resource %q is required", id)
+	}
+	if !utf8.ValidString(name) {
+		return errors.Newf("display name of resource %q is not valid UTF-8", id)
+	}
+	return nil
 }
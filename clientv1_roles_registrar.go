@@ -0,0 +1,272 @@
+package sams
+
+import (
+	"context"
+	"iter"
+	"math/rand/v2"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/background"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/atomic"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+const (
+	defaultRoleResourcesRegistrarInterval      = 10 * time.Minute
+	defaultRoleResourcesRegistrarRetryInterval = 30 * time.Second
+)
+
+// RoleResourcesRegistrarOptions configures NewRoleResourcesRegistrar.
+type RoleResourcesRegistrarOptions struct {
+	// ResourceType is the type of resources to register.
+	ResourceType roles.ResourceType
+	// Resources returns the complete set of resources of ResourceType owned by
+	// the service. It is called once per registration.
+	Resources func(ctx context.Context) iter.Seq2[*clientsv1.RoleResource, error]
+	// RegisterOptions configures how resources are registered, see
+	// RolesServiceV1.RegisterRoleResourcesSeq. Setting MaxRemovalPercent is
+	// strongly recommended, in which case registrations fail with ErrUnsupported
+	// instead of being committed unguarded if SAMS does not support it.
+	RegisterOptions RegisterRoleResourcesOptions
+	// Interval is the duration between successful registrations.
+	//
+	// The default of 0 (or less) uses 10 minutes.
+	Interval time.Duration
+	// RetryInterval is the initial duration to wait before retrying a failed
+	// registration, doubling on each consecutive failure up to Interval.
+	//
+	// The default of 0 (or less) uses 30 seconds.
+	RetryInterval time.Duration
+	// Jitter is the maximum random duration added to each wait, including before
+	// the first registration, so that replicas do not register at the same time.
+	//
+	// The default of 0 uses a tenth of Interval, and a negative value disables
+	// jitter.
+	Jitter time.Duration
+	// MeterProvider is used to create the metrics of the registrar.
+	//
+	// The default is the global meter provider.
+	MeterProvider metric.MeterProvider
+}
+
+func (opts RoleResourcesRegistrarOptions) Validate() error {
+	if err := (RegisterResourcesMetadata{ResourceType: opts.ResourceType}).validate(); err != nil {
+		return err
+	}
+	if err := opts.RegisterOptions.validate(); err != nil {
+		return errors.Wrap(err, "invalid RegisterOptions")
+	}
+	if opts.Resources == nil {
+		return errors.New("Resources is required")
+	}
+	return nil
+}
+
+// NewRoleResourcesRegistrar returns a background routine that periodically
+// registers the complete set of resources of a resource type with SAMS, using
+// RolesServiceV1.RegisterRoleResourcesSeq.
+//
+// It is safe to run the routine on every replica of the service: if another
+// replica is already registering the same resource type, the registration is
+// skipped and considered successful.
+//
+// The routine reports the following metrics, with the "resource_type"
+// attribute:
+//   - sams.role_resources_registrar.last_success: Unix timestamp of the last
+//     successful registration.
+//   - sams.role_resources_registrar.resource_count: Number of resources
+//     registered by the last successful registration of this replica.
+//   - sams.role_resources_registrar.failures: Number of failed registrations.
+//
+// Required scope: sams::roles.resources::write
+func NewRoleResourcesRegistrar(logger log.Logger, rolesService *RolesServiceV1, opts RoleResourcesRegistrarOptions) (background.Routine, error) {
+	if rolesService == nil {
+		return nil, errors.New("roles service is required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid options")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultRoleResourcesRegistrarInterval
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultRoleResourcesRegistrarRetryInterval
+	}
+	if opts.Jitter == 0 {
+		opts.Jitter = opts.Interval / 10
+	}
+	if opts.MeterProvider == nil {
+		opts.MeterProvider = otel.GetMeterProvider()
+	}
+
+	logger = logger.Scoped("roleResourcesRegistrar").
+		With(log.String("resourceType", string(opts.ResourceType)))
+	r := &roleResourcesRegistrar{
+		logger: logger,
+		roles:  rolesService,
+		opts:   opts,
+		runner: newRoutineRunner(logger),
+	}
+	if err := r.registerMetrics(); err != nil {
+		return nil, errors.Wrap(err, "register metrics")
+	}
+	return r, nil
+}
+
+type roleResourcesRegistrar struct {
+	logger log.Logger
+	roles  *RolesServiceV1
+	opts   RoleResourcesRegistrarOptions
+
+	// lastSuccess is the Unix timestamp of the last successful registration, or
+	// 0 if there was none.
+	lastSuccess atomic.Int64
+	// resourceCount is the number of resources registered by the last successful
+	// registration.
+	resourceCount atomic.Int64
+	failures      metric.Int64Counter
+
+	runner *routineRunner
+}
+
+func (r *roleResourcesRegistrar) registerMetrics() error {
+	meter := r.opts.MeterProvider.Meter("sams/roleresourcesregistrar")
+	attrs := metric.WithAttributes(attribute.String("resource_type", string(r.opts.ResourceType)))
+
+	lastSuccess, err := meter.Int64ObservableGauge(
+		"sams.role_resources_registrar.last_success",
+		metric.WithDescription("Unix timestamp of the last successful registration of role resources."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	resourceCount, err := meter.Int64ObservableGauge(
+		"sams.role_resources_registrar.resource_count",
+		metric.WithDescription("Number of role resources registered by the last successful registration."),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		if v := r.lastSuccess.Load(); v > 0 {
+			o.ObserveInt64(lastSuccess, v, attrs)
+			o.ObserveInt64(resourceCount, r.resourceCount.Load(), attrs)
+		}
+		return nil
+	}, lastSuccess, resourceCount)
+	if err != nil {
+		return err
+	}
+
+	failures, err := meter.Int64Counter(
+		"sams.role_resources_registrar.failures",
+		metric.WithDescription("Number of failed registrations of role resources."),
+	)
+	if err != nil {
+		return err
+	}
+	r.failures = failures
+	return nil
+}
+
+func (r *roleResourcesRegistrar) Name() string {
+	return "SAMS Role Resources Registrar"
+}
+
+func (r *roleResourcesRegistrar) Start() {
+	r.runner.run("role resources registrar", r.loop)
+}
+
+func (r *roleResourcesRegistrar) loop(ctx context.Context) {
+	r.logger.Info("started", log.Duration("interval", r.opts.Interval))
+
+	wait := r.jitter()
+	var consecutiveFailures int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if err := r.register(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			consecutiveFailures++
+			wait = r.retryInterval(consecutiveFailures) + r.jitter()
+			r.logger.Error("failed to register role resources",
+				log.Int("consecutiveFailures", consecutiveFailures),
+				log.Duration("retryIn", wait),
+				log.Error(err))
+			continue
+		}
+		consecutiveFailures = 0
+		wait = r.opts.Interval + r.jitter()
+	}
+}
+
+// register registers the resources once, and records the outcome.
+func (r *roleResourcesRegistrar) register(ctx context.Context) error {
+	start := time.Now()
+	count, err := r.roles.RegisterRoleResourcesSeq(
+		ctx,
+		RegisterResourcesMetadata{ResourceType: r.opts.ResourceType},
+		r.opts.Resources(ctx),
+		r.opts.RegisterOptions,
+	)
+	if err != nil {
+		if errors.Is(err, ErrAborted) {
+			// Another replica is registering the same resources, which is as good
+			// as us doing it.
+			r.lastSuccess.Store(time.Now().Unix())
+			r.logger.Info("skipped registration, another replica is registering role resources")
+			return nil
+		}
+		if ctx.Err() == nil {
+			r.failures.Add(context.Background(), 1,
+				metric.WithAttributes(attribute.String("resource_type", string(r.opts.ResourceType))))
+		}
+		return err
+	}
+
+	r.lastSuccess.Store(time.Now().Unix())
+	r.resourceCount.Store(int64(count))
+	r.logger.Info("registered role resources",
+		log.Uint64("resourceCount", count),
+		log.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// retryInterval returns the duration to wait after the given number of
+// consecutive failures, which is RetryInterval doubled for each additional
+// failure, capped at Interval.
+func (r *roleResourcesRegistrar) retryInterval(consecutiveFailures int) time.Duration {
+	wait := r.opts.RetryInterval
+	for range consecutiveFailures - 1 {
+		wait *= 2
+		if wait >= r.opts.Interval {
+			return r.opts.Interval
+		}
+	}
+	return min(wait, r.opts.Interval)
+}
+
+func (r *roleResourcesRegistrar) jitter() time.Duration {
+	if r.opts.Jitter <= 0 {
+		return 0
+	}
+	return rand.N(r.opts.Jitter)
+}
+
+func (r *roleResourcesRegistrar) Stop(ctx context.Context) error {
+	return r.runner.stop(ctx)
+}
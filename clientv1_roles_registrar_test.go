@@ -0,0 +1,135 @@
+package sams
+
+import (
+	"context"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+func TestRoleResourcesRegistrar(t *testing.T) {
+	// runRegistrar runs the registrar until the resources have been requested
+	// the given number of times, i.e. the previous registrations have completed.
+	runRegistrar := func(t *testing.T, svc *fakeRolesService, resources iter.Seq2[*clientsv1.RoleResource, error], calls int) *roleResourcesRegistrar {
+		called := make(chan struct{})
+		routine, err := NewRoleResourcesRegistrar(logtest.Scoped(t), newTestRolesService(t, svc), RoleResourcesRegistrarOptions{
+			ResourceType: roles.EnterpriseSubscription,
+			Resources: func(context.Context) iter.Seq2[*clientsv1.RoleResource, error] {
+				called <- struct{}{}
+				return resources
+			},
+			Interval:      time.Millisecond,
+			RetryInterval: time.Millisecond,
+			Jitter:        -1,
+		})
+		require.NoError(t, err)
+
+		go routine.Start()
+		for range calls {
+			select {
+			case <-called:
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for registration")
+			}
+		}
+		// Unblock any further registration while stopping.
+		go func() {
+			for range called {
+			}
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, routine.Stop(ctx))
+		close(called)
+		return routine.(*roleResourcesRegistrar)
+	}
+
+	t.Run("registers periodically", func(t *testing.T) {
+		svc := &fakeRolesService{}
+		r := runRegistrar(t, svc, roleResourcesSeq(5), 3)
+		assert.NotZero(t, r.lastSuccess.Load())
+		assert.Equal(t, int64(5), r.resourceCount.Load())
+		assert.GreaterOrEqual(t, len(svc.batches), 2)
+	})
+
+	t.Run("another replica is registering", func(t *testing.T) {
+		svc := &fakeRolesService{abort: true}
+		r := runRegistrar(t, svc, roleResourcesSeq(5), 2)
+		assert.NotZero(t, r.lastSuccess.Load())
+		assert.Zero(t, r.resourceCount.Load())
+	})
+
+	t.Run("retries on failure", func(t *testing.T) {
+		svc := &fakeRolesService{}
+		r := runRegistrar(t, svc,
+			func(yield func(*clientsv1.RoleResource, error) bool) {
+				yield(nil, errors.New("database is down"))
+			},
+			3)
+		assert.Zero(t, r.lastSuccess.Load())
+		assert.Empty(t, svc.batches)
+	})
+
+	t.Run("stop before start", func(t *testing.T) {
+		svc := &fakeRolesService{}
+		routine, err := NewRoleResourcesRegistrar(logtest.Scoped(t), newTestRolesService(t, svc), RoleResourcesRegistrarOptions{
+			ResourceType: roles.EnterpriseSubscription,
+			Resources: func(context.Context) iter.Seq2[*clientsv1.RoleResource, error] {
+				return roleResourcesSeq(5)
+			},
+			Interval:      time.Millisecond,
+			RetryInterval: time.Millisecond,
+			Jitter:        -1,
+		})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, routine.Stop(ctx))
+
+		started := make(chan struct{})
+		go func() {
+			routine.Start()
+			close(started)
+		}()
+		select {
+		case <-started:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for Start to return")
+		}
+		assert.Empty(t, svc.batches)
+	})
+}
+
+func TestRoleResourcesRegistrarRetryInterval(t *testing.T) {
+	r := &roleResourcesRegistrar{opts: RoleResourcesRegistrarOptions{
+		Interval:      10 * time.Minute,
+		RetryInterval: time.Minute,
+	}}
+	var got []time.Duration
+	for failures := 1; failures <= 6; failures++ {
+		got = append(got, r.retryInterval(failures))
+	}
+	assert.Equal(t, []time.Duration{
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		10 * time.Minute,
+		10 * time.Minute,
+	}, got)
+}
+
+func TestRoleResourcesRegistrarOptionsValidate(t *testing.T) {
+	resources := func(context.Context) iter.Seq2[*clientsv1.RoleResource, error] { return roleResourcesSeq(0) }
+	assert.NoError(t, RoleResourcesRegistrarOptions{ResourceType: roles.EnterpriseSubscription, Resources: resources}.Validate())
+	assert.EqualError(t, RoleResourcesRegistrarOptions{ResourceType: "foo", Resources: resources}.Validate(), `invalid resource type: "foo"`)
+	assert.EqualError(t, RoleResourcesRegistrarOptions{ResourceType: roles.EnterpriseSubscription}.Validate(), "Resources is required")
+}
@@ -0,0 +1,365 @@
+package sams
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/roles"
+)
+
+func roleResourcesSeq(n int) iter.Seq2[*clientsv1.RoleResource, error] {
+	return func(yield func(*clientsv1.RoleResource, error) bool) {
+		for i := range n {
+			if !yield(&clientsv1.RoleResource{
+				ResourceId:  fmt.Sprintf("sub-%03d", i),
+				DisplayName: fmt.Sprintf("Subscription %03d", i),
+			}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestBatchRoleResources(t *testing.T) {
+	// Each resource generated by roleResourcesSeq is 29 bytes when encoded as an
+	// element of the repeated field.
+	for _, tc := range []struct {
+		name        string
+		resources   iter.Seq2[*clientsv1.RoleResource, error]
+		opts        RegisterRoleResourcesOptions
+		wantBatches []int
+		wantErr     string
+	}{
+		{
+			name:        "by count",
+			resources:   roleResourcesSeq(25),
+			opts:        RegisterRoleResourcesOptions{MaxBatchCount: 10, MaxBatchBytes: 1 << 20},
+			wantBatches: []int{10, 10, 5},
+		},
+		{
+			name:        "by bytes",
+			resources:   roleResourcesSeq(25),
+			opts:        RegisterRoleResourcesOptions{MaxBatchCount: 10, MaxBatchBytes: 29 * 4},
+			wantBatches: []int{4, 4, 4, 4, 4, 4, 1},
+		},
+		{
+			name:        "empty",
+			resources:   roleResourcesSeq(0),
+			opts:        RegisterRoleResourcesOptions{MaxBatchCount: 10, MaxBatchBytes: 1 << 20},
+			wantBatches: nil,
+		},
+		{
+			name:      "resource too large",
+			resources: roleResourcesSeq(1),
+			opts:      RegisterRoleResourcesOptions{MaxBatchCount: 10, MaxBatchBytes: 28},
+			wantErr:   `resource "sub-000" exceeds the maximum batch size of 28 bytes`,
+		},
+		{
+			name: "invalid resource",
+			resources: func(yield func(*clientsv1.RoleResource, error) bool) {
+				for r := range roleResourcesSeq(3) {
+					if !yield(r, nil) {
+						return
+					}
+				}
+				yield(&clientsv1.RoleResource{ResourceId: "sub-003"}, nil)
+			},
+			opts:    RegisterRoleResourcesOptions{MaxBatchCount: 2, MaxBatchBytes: 1 << 20},
+			wantErr: `invalid resource at index 3: display name of resource "sub-003" is required`,
+		},
+		{
+			name: "iterator error",
+			resources: func(yield func(*clientsv1.RoleResource, error) bool) {
+				yield(nil, errors.New("database is down"))
+			},
+			opts:    RegisterRoleResourcesOptions{MaxBatchCount: 2, MaxBatchBytes: 1 << 20},
+			wantErr: "failed to get resources: database is down",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var batches []int
+			var progress []RegisterRoleResourcesProgress
+			tc.opts.OnProgress = func(p RegisterRoleResourcesProgress) {
+				progress = append(progress, p)
+			}
+			err := batchRoleResources(tc.resources, tc.opts, func(resources []*clientsv1.RoleResource) error {
+				batches = append(batches, len(resources))
+				return nil
+			})
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantBatches, batches)
+			if assert.Len(t, progress, len(batches)) && len(batches) > 0 {
+				last := progress[len(progress)-1]
+				assert.Equal(t, len(batches), last.Batches)
+				assert.Equal(t, 25, last.Resources)
+				assert.Equal(t, 25*29, last.Bytes)
+			}
+		})
+	}
+}
+
+func TestValidateRoleResource(t *testing.T) {
+	for _, tc := range []struct {
+		resource *clientsv1.RoleResource
+		wantErr  string
+	}{
+		{resource: &clientsv1.RoleResource{ResourceId: "sub-1", DisplayName: "Subscription 1"}},
+		{resource: nil, wantErr: "resource cannot be nil"},
+		{resource: &clientsv1.RoleResource{DisplayName: "Subscription 1"}, wantErr: "resource ID is required"},
+		{resource: &clientsv1.RoleResource{ResourceId: " sub-1", DisplayName: "Subscription 1"}, wantErr: `resource ID " sub-1" has leading or trailing whitespace`},
+		{resource: &clientsv1.RoleResource{ResourceId: "sub-\xff", DisplayName: "Subscription 1"}, wantErr: `resource ID "sub-\xff" is not valid UTF-8`},
+		{resource: &clientsv1.RoleResource{ResourceId: "sub-1", DisplayName: "  "}, wantErr: `display name of resource "sub-1" is required`},
+		{resource: &clientsv1.RoleResource{ResourceId: "sub-1", DisplayName: "Subscription \xff"}, wantErr: `display name of resource "sub-1" is not valid UTF-8`},
+	} {
+		err := validateRoleResource(tc.resource)
+		if tc.wantErr == "" {
+			assert.NoError(t, err)
+		} else if assert.Error(t, err) {
+			assert.Equal(t, tc.wantErr, err.Error())
+		}
+	}
+}
+
+func TestRoleResourcesFromChan(t *testing.T) {
+	t.Run("closed", func(t *testing.T) {
+		ch := make(chan *clientsv1.RoleResource, 2)
+		ch <- &clientsv1.RoleResource{ResourceId: "a"}
+		ch <- &clientsv1.RoleResource{ResourceId: "b"}
+		close(ch)
+		var got []string
+		for r, err := range RoleResourcesFromChan(context.Background(), ch) {
+			require.NoError(t, err)
+			got = append(got, r.GetResourceId())
+		}
+		assert.Equal(t, []string{"a", "b"}, got)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var gotErr error
+		for _, err := range RoleResourcesFromChan(ctx, make(chan *clientsv1.RoleResource)) {
+			gotErr = err
+		}
+		assert.ErrorIs(t, gotErr, context.Canceled)
+	})
+}
+
+type fakeRolesService struct {
+	clientsv1connect.UnimplementedRolesServiceHandler
+	// abort, if true, makes RegisterRoleResources return CodeAborted after
+	// receiving the metadata.
+	abort bool
+	// ignoreDryRun, if true, simulates a server that does not support dry runs.
+	ignoreDryRun bool
+	// capabilities, if set, is returned by GetRoleResourcesCapabilities.
+	// Otherwise, it returns CodeUnimplemented like an older server.
+	capabilities *clientsv1.GetRoleResourcesCapabilitiesResponse
+	// existing is the set of registered resource IDs.
+	existing []string
+
+	metadata *clientsv1.RegisterRoleResourcesRequestMetadata
+	batches  [][]string
+}
+
+func (s *fakeRolesService) RegisterRoleResources(_ context.Context, stream *connect.ClientStream[clientsv1.RegisterRoleResourcesRequest]) (*connect.Response[clientsv1.RegisterRoleResourcesResponse], error) {
+	var count uint64
+	for stream.Receive() {
+		switch {
+		case stream.Msg().GetMetadata() != nil:
+			s.metadata = stream.Msg().GetMetadata()
+			if s.abort {
+				return nil, connect.NewError(connect.CodeAborted, errors.New("registration in progress"))
+			}
+		case stream.Msg().GetResources() != nil:
+			var batch []string
+			for _, r := range stream.Msg().GetResources().GetResources() {
+				batch = append(batch, r.GetResourceId())
+			}
+			s.batches = append(s.batches, batch)
+			count += uint64(len(batch))
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+
+	registered := make(map[string]bool)
+	for _, batch := range s.batches {
+		for _, id := range batch {
+			registered[id] = true
+		}
+	}
+	resp := &clientsv1.RegisterRoleResourcesResponse{ResourceCount: count}
+	for _, id := range s.existing {
+		if !registered[id] {
+			resp.RemovedResourceIds = append(resp.RemovedResourceIds, id)
+		}
+		delete(registered, id)
+	}
+	for id := range registered {
+		resp.AddedResourceIds = append(resp.AddedResourceIds, id)
+	}
+	slices.Sort(resp.AddedResourceIds)
+
+	if threshold := s.metadata.GetMaxRemovalPercent(); threshold > 0 &&
+		len(resp.RemovedResourceIds)*100 > int(threshold)*len(s.existing) {
+		detail, err := connect.NewErrorDetail(&clientsv1.ErrorRemovalThresholdExceeded{
+			ExistingResourceCount: uint64(len(s.existing)),
+			RemovedResourceCount:  uint64(len(resp.RemovedResourceIds)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		connectErr := connect.NewError(connect.CodeFailedPrecondition, errors.New("removal threshold exceeded"))
+		connectErr.AddDetail(detail)
+		return nil, connectErr
+	}
+	resp.DryRun = s.metadata.GetDryRun() && !s.ignoreDryRun
+	return connect.NewResponse(resp), nil
+}
+
+func (s *fakeRolesService) GetRoleResourcesCapabilities(context.Context, *connect.Request[clientsv1.GetRoleResourcesCapabilitiesRequest]) (*connect.Response[clientsv1.GetRoleResourcesCapabilitiesResponse], error) {
+	if s.capabilities == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("not implemented"))
+	}
+	return connect.NewResponse(s.capabilities), nil
+}
+
+// allCapabilities is the capabilities of a server that supports all optional
+// RegisterRoleResources features.
+var allCapabilities = &clientsv1.GetRoleResourcesCapabilitiesResponse{DryRun: true, MaxRemovalPercent: true}
+
+func newTestRolesService(t *testing.T, svc *fakeRolesService) *RolesServiceV1 {
+	path, handler := clientsv1connect.NewRolesServiceHandler(svc)
+	mux := http.NewServeMux()
+	mux.Handle("/api/grpc"+path, http.StripPrefix("/api/grpc", handler))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClientV1(ClientV1Config{
+		ConnConfig:  ConnConfig{ExternalURL: srv.URL},
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "sams_at_test"}),
+	})
+	require.NoError(t, err)
+	return c.Roles()
+}
+
+func TestRegisterRoleResourcesSeq(t *testing.T) {
+	ctx := context.Background()
+	metadata := RegisterResourcesMetadata{ResourceType: roles.EnterpriseSubscription}
+
+	t.Run("batches", func(t *testing.T) {
+		svc := &fakeRolesService{}
+		count, err := newTestRolesService(t, svc).RegisterRoleResourcesSeq(ctx, metadata, roleResourcesSeq(5), RegisterRoleResourcesOptions{MaxBatchCount: 2})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), count)
+		assert.Equal(t, string(roles.EnterpriseSubscription), svc.metadata.GetResourceType())
+		assert.Equal(t, [][]string{{"sub-000", "sub-001"}, {"sub-002", "sub-003"}, {"sub-004"}}, svc.batches)
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		svc := &fakeRolesService{abort: true}
+		_, err := newTestRolesService(t, svc).RegisterRoleResourcesSeq(ctx, metadata, roleResourcesSeq(5), RegisterRoleResourcesOptions{MaxBatchCount: 2})
+		assert.ErrorIs(t, err, ErrAborted)
+
+		// RegisterRoleResources swallows ErrAborted for backwards compatibility.
+		count, err := newTestRolesService(t, svc).RegisterRoleResources(ctx, metadata, func() ([]*clientsv1.RoleResource, error) {
+			return []*clientsv1.RoleResource{{ResourceId: "sub-000", DisplayName: "Subscription 0"}}, nil
+		})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("invalid resource", func(t *testing.T) {
+		svc := &fakeRolesService{}
+		_, err := newTestRolesService(t, svc).RegisterRoleResourcesSeq(ctx, metadata,
+			func(yield func(*clientsv1.RoleResource, error) bool) {
+				yield(&clientsv1.RoleResource{ResourceId: "sub-000"}, nil)
+			},
+			RegisterRoleResourcesOptions{},
+		)
+		require.Error(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "invalid resource at index 0"))
+		assert.Empty(t, svc.batches)
+	})
+}
+
+func TestDiffRoleResources(t *testing.T) {
+	ctx := context.Background()
+	metadata := RegisterResourcesMetadata{ResourceType: roles.EnterpriseSubscription}
+	existing := []string{"sub-003", "sub-004", "sub-005", "sub-006"}
+
+	t.Run("diff", func(t *testing.T) {
+		svc := &fakeRolesService{existing: existing, capabilities: allCapabilities}
+		diff, err := newTestRolesService(t, svc).DiffRoleResources(ctx, metadata, roleResourcesSeq(5), RegisterRoleResourcesOptions{})
+		require.NoError(t, err)
+		assert.True(t, svc.metadata.GetDryRun())
+		assert.Equal(t, &RoleResourcesDiff{
+			ResourceCount: 5,
+			Added:         []string{"sub-000", "sub-001", "sub-002"},
+			Removed:       []string{"sub-005", "sub-006"},
+		}, diff)
+	})
+
+	t.Run("removal threshold exceeded", func(t *testing.T) {
+		svc := &fakeRolesService{existing: existing, capabilities: allCapabilities}
+		_, err := newTestRolesService(t, svc).DiffRoleResources(ctx, metadata, roleResourcesSeq(5), RegisterRoleResourcesOptions{MaxRemovalPercent: 25})
+		assert.ErrorIs(t, err, ErrRemovalThresholdExceeded)
+		assert.Equal(t, uint32(25), svc.metadata.GetMaxRemovalPercent())
+
+		_, err = newTestRolesService(t, svc).RegisterRoleResourcesSeq(ctx, metadata, roleResourcesSeq(0), RegisterRoleResourcesOptions{MaxRemovalPercent: 25})
+		assert.ErrorIs(t, err, ErrRemovalThresholdExceeded)
+		assert.False(t, svc.metadata.GetDryRun())
+	})
+
+	t.Run("dry run not supported", func(t *testing.T) {
+		svc := &fakeRolesService{existing: existing, ignoreDryRun: true}
+		_, err := newTestRolesService(t, svc).DiffRoleResources(ctx, metadata, roleResourcesSeq(5), RegisterRoleResourcesOptions{})
+		assert.ErrorIs(t, err, ErrUnsupported)
+		assert.Nil(t, svc.metadata, "no resources should be registered")
+
+		svc = &fakeRolesService{existing: existing, ignoreDryRun: true, capabilities: &clientsv1.GetRoleResourcesCapabilitiesResponse{MaxRemovalPercent: true}}
+		_, err = newTestRolesService(t, svc).DiffRoleResources(ctx, metadata, roleResourcesSeq(5), RegisterRoleResourcesOptions{})
+		assert.ErrorIs(t, err, ErrUnsupported)
+		assert.Nil(t, svc.metadata, "no resources should be registered")
+	})
+
+	t.Run("removal threshold not supported", func(t *testing.T) {
+		svc := &fakeRolesService{existing: existing}
+		_, err := newTestRolesService(t, svc).RegisterRoleResourcesSeq(ctx, metadata, roleResourcesSeq(0), RegisterRoleResourcesOptions{MaxRemovalPercent: 25})
+		assert.ErrorIs(t, err, ErrUnsupported)
+		assert.Nil(t, svc.metadata, "no resources should be registered")
+
+		svc = &fakeRolesService{existing: existing, capabilities: &clientsv1.GetRoleResourcesCapabilitiesResponse{DryRun: true}}
+		_, err = newTestRolesService(t, svc).RegisterRoleResourcesSeq(ctx, metadata, roleResourcesSeq(0), RegisterRoleResourcesOptions{MaxRemovalPercent: 25})
+		assert.ErrorIs(t, err, ErrUnsupported)
+		assert.Nil(t, svc.metadata, "no resources should be registered")
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		svc := &fakeRolesService{}
+		_, err := newTestRolesService(t, svc).DiffRoleResources(ctx, metadata, roleResourcesSeq(5), RegisterRoleResourcesOptions{MaxRemovalPercent: 101})
+		assert.EqualError(t, err, "invalid options: MaxRemovalPercent must be between 0 and 100, got 101")
+		assert.Nil(t, svc.metadata)
+	})
+}
@@ -2,6 +2,7 @@ package sams
 
 import (
 	"context"
+	"iter"
 	"time"
 
 	"connectrpc.com/connect"
@@ -40,7 +41,7 @@ type CreateServiceAccessTokenOptions struct {
 // CreateServiceAccessTokenResponse represents the response from creating a service access token.
 type CreateServiceAccessTokenResponse struct {
 	Token  *clientsv1.ServiceAccessToken
-	Secret string
+	Secret Secret
 }
 
 // CreateServiceAccessToken creates a new service access token.
@@ -56,6 +57,9 @@ func (s *ServiceAccessTokensServiceV1) CreateServiceAccessToken(ctx context.Cont
 	if userID == "" {
 		return nil, errors.New("user ID cannot be empty")
 	}
+	if err := validateServiceAccessTokenScopes(service, tokenScopes); err != nil {
+		return nil, errors.Wrap(err, "invalid scopes")
+	}
 
 	token := &clientsv1.ServiceAccessToken{
 		Service:     string(service),
@@ -77,10 +81,30 @@ func (s *ServiceAccessTokensServiceV1) CreateServiceAccessToken(ctx context.Cont
 
 	return &CreateServiceAccessTokenResponse{
 		Token:  resp.Msg.Token,
-		Secret: resp.Msg.Secret,
+		Secret: Secret(resp.Msg.GetSecret()),
 	}, nil
 }
 
+// validateServiceAccessTokenScopes returns an error for each scope that is
+// malformed, not allowed, or does not belong to the service, as service access
+// tokens can only have scopes of their own service.
+func validateServiceAccessTokenScopes(service services.Service, tokenScopes []scopes.Scope) error {
+	allowed := scopes.Allowed()
+	var errs error
+	for _, scope := range tokenScopes {
+		parsed, valid := scopes.ParseScope(scope)
+		switch {
+		case !valid:
+			errs = errors.Append(errs, errors.Newf("scope %q is malformed", scope))
+		case !allowed.Contains(scope):
+			errs = errors.Append(errs, errors.Newf("scope %q is not allowed", scope))
+		case parsed.Service != service:
+			errs = errors.Append(errs, errors.Newf("scope %q does not belong to service %q", scope, service))
+		}
+	}
+	return errs
+}
+
 // ListServiceAccessTokensOptions represents the options for listing service access tokens.
 type ListServiceAccessTokensOptions struct {
 	// Maximum number of results to return (optional).
@@ -95,11 +119,23 @@ type ListServiceAccessTokensOptions struct {
 	ShowExpired bool
 }
 
-// ListServiceAccessTokens returns a list of service access tokens in reverse chronological
-// order by creation time.
+// ListServiceAccessTokens returns a single page of service access tokens in
+// reverse chronological order by creation time. Use ListServiceAccessTokensPage
+// to also get the token of the next page, or All and ListAll to list tokens
+// across all pages.
 //
 // Required scope: sams::service_access_tokens::read
 func (s *ServiceAccessTokensServiceV1) ListServiceAccessTokens(ctx context.Context, opts ListServiceAccessTokensOptions) ([]*clientsv1.ServiceAccessToken, error) {
+	tokens, _, err := s.ListServiceAccessTokensPage(ctx, opts)
+	return tokens, err
+}
+
+// ListServiceAccessTokensPage returns a single page of service access tokens in
+// reverse chronological order by creation time, and the token of the next page,
+// which is empty if there are no subsequent pages.
+//
+// Required scope: sams::service_access_tokens::read
+func (s *ServiceAccessTokensServiceV1) ListServiceAccessTokensPage(ctx context.Context, opts ListServiceAccessTokensOptions) (_ []*clientsv1.ServiceAccessToken, nextPageToken string, _ error) {
 	req := &clientsv1.ListServiceAccessTokensRequest{
 		PageSize:  opts.PageSize,
 		PageToken: opts.PageToken,
@@ -127,9 +163,56 @@ func (s *ServiceAccessTokensServiceV1) ListServiceAccessTokens(ctx context.Conte
 	client := s.newClient(ctx)
 	resp, err := parseResponseAndError(client.ListServiceAccessTokens(ctx, connect.NewRequest(req)))
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	return resp.Msg.GetTokens(), resp.Msg.GetNextPageToken(), nil
+}
+
+// All returns an iterator over the service access tokens across all pages, in
+// reverse chronological order by creation time, starting from opts.PageToken.
+// The filters of opts apply to every page, and opts.PageSize is the size of
+// each page. Iteration stops at the first error, which is yielded with a nil
+// token.
+//
+// Required scope: sams::service_access_tokens::read
+func (s *ServiceAccessTokensServiceV1) All(ctx context.Context, opts ListServiceAccessTokensOptions) iter.Seq2[*clientsv1.ServiceAccessToken, error] {
+	return func(yield func(*clientsv1.ServiceAccessToken, error) bool) {
+		for {
+			tokens, nextPageToken, err := s.ListServiceAccessTokensPage(ctx, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, token := range tokens {
+				if !yield(token, nil) {
+					return
+				}
+			}
+			if nextPageToken == "" {
+				return
+			}
+			if nextPageToken == opts.PageToken {
+				// Guard against looping forever on the same page.
+				yield(nil, errors.Newf("server returned the same page token %q", nextPageToken))
+				return
+			}
+			opts.PageToken = nextPageToken
+		}
 	}
-	return resp.Msg.GetTokens(), nil
+}
+
+// ListAll returns the service access tokens across all pages, see All.
+//
+// Required scope: sams::service_access_tokens::read
+func (s *ServiceAccessTokensServiceV1) ListAll(ctx context.Context, opts ListServiceAccessTokensOptions) ([]*clientsv1.ServiceAccessToken, error) {
+	var tokens []*clientsv1.ServiceAccessToken
+	for token, err := range s.All(ctx, opts) {
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
 }
 
 // RevokeServiceAccessToken revokes the specified service access token.
@@ -145,3 +228,133 @@ func (s *ServiceAccessTokensServiceV1) RevokeServiceAccessToken(ctx context.Cont
 	_, err := parseResponseAndError(client.RevokeServiceAccessToken(ctx, connect.NewRequest(req)))
 	return err
 }
+
+// RotateServiceAccessTokenOptions represents the options for rotating a service
+// access token.
+type RotateServiceAccessTokenOptions struct {
+	// Publish is called with the replacement token and its secret, and MUST
+	// distribute the secret to wherever the old token is used, e.g. a secret
+	// manager. If it returns an error, the replacement token is revoked and the
+	// old token is kept.
+	Publish func(ctx context.Context, replacement *CreateServiceAccessTokenResponse) error
+	// GracePeriod is the duration to wait after publishing before revoking the old
+	// token, so that consumers have time to pick up the new secret (optional,
+	// defaults to revoking immediately unless Confirmed is set).
+	GracePeriod time.Duration
+	// Confirmed, if set, revokes the old token as soon as it is closed, e.g. once
+	// all consumers have reported using the new secret, even if GracePeriod has
+	// not elapsed yet. Without a GracePeriod, the old token is revoked only when
+	// Confirmed is closed (optional).
+	Confirmed <-chan struct{}
+	// The time the replacement token will expire (optional, defaults to the same
+	// lifetime as the old token but at least one hour, or never expire if the old
+	// token never expires). It MUST be in the future.
+	ExpiresAt *time.Time
+}
+
+// minRotatedServiceAccessTokenLifetime is the minimum lifetime of a replacement
+// token that inherits the lifetime of the old token, so that a malformed old
+// token does not result in a replacement that is already expired.
+const minRotatedServiceAccessTokenLifetime = time.Hour
+
+// RotateServiceAccessTokenResponse represents the response from rotating a
+// service access token.
+type RotateServiceAccessTokenResponse struct {
+	// Replacement is the newly created token and its secret.
+	Replacement *CreateServiceAccessTokenResponse
+	// OldTokenRevoked is true if the old token has been revoked.
+	OldTokenRevoked bool
+}
+
+// RotateServiceAccessToken creates a replacement of the old token with the same
+// service, scopes, user and display name, hands its secret to opts.Publish, and
+// then revokes the old token after opts.GracePeriod, or as soon as
+// opts.Confirmed is closed.
+//
+// If the context is canceled while waiting to revoke the old token, the
+// replacement token is returned along with an error, and the old token MUST be
+// revoked later with RevokeServiceAccessToken.
+//
+// Required scopes: sams::service_access_tokens::write and
+// sams::service_access_tokens::delete
+func (s *ServiceAccessTokensServiceV1) RotateServiceAccessToken(ctx context.Context, old *clientsv1.ServiceAccessToken, opts RotateServiceAccessTokenOptions) (*RotateServiceAccessTokenResponse, error) {
+	if old.GetId() == "" {
+		return nil, errors.New("old token ID cannot be empty")
+	}
+	if opts.Publish == nil {
+		return nil, errors.New("Publish is required")
+	}
+
+	now := time.Now()
+	expiresAt := opts.ExpiresAt
+	if expiresAt == nil && old.GetExpireTime() != nil && old.GetCreationTime() != nil {
+		lifetime := old.GetExpireTime().AsTime().Sub(old.GetCreationTime().AsTime())
+		t := now.Add(max(lifetime, minRotatedServiceAccessTokenLifetime))
+		expiresAt = &t
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.Newf("ExpiresAt %s is not in the future", expiresAt.Format(time.RFC3339))
+	}
+
+	// The replacement is created with the same scopes, which may no longer be
+	// allowed since the old token was created.
+	service := services.Service(old.GetService())
+	tokenScopes := scopes.ToScopes(old.GetScopes())
+	if err := validateServiceAccessTokenScopes(service, tokenScopes); err != nil {
+		return nil, errors.Wrapf(err, "old token %q has scopes that are no longer allowed, create a new token with allowed scopes instead", old.GetId())
+	}
+
+	replacement, err := s.CreateServiceAccessToken(
+		ctx,
+		service,
+		tokenScopes,
+		old.GetUserId(),
+		CreateServiceAccessTokenOptions{
+			DisplayName: old.GetDisplayName(),
+			ExpiresAt:   expiresAt,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "create replacement token")
+	}
+
+	if err = opts.Publish(ctx, replacement); err != nil {
+		err = errors.Wrap(err, "publish replacement token")
+		if revokeErr := s.RevokeServiceAccessToken(context.WithoutCancel(ctx), replacement.Token.GetId()); revokeErr != nil {
+			err = errors.Append(err, errors.Wrapf(revokeErr, "revoke replacement token %q", replacement.Token.GetId()))
+		}
+		return nil, err
+	}
+
+	resp := &RotateServiceAccessTokenResponse{Replacement: replacement}
+	if err = waitForRevocation(ctx, opts.GracePeriod, opts.Confirmed); err != nil {
+		return resp, errors.Wrapf(err, "old token %q was not revoked", old.GetId())
+	}
+	err = s.RevokeServiceAccessToken(ctx, old.GetId())
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return resp, errors.Wrapf(err, "revoke old token %q", old.GetId())
+	}
+	resp.OldTokenRevoked = true
+	return resp, nil
+}
+
+// waitForRevocation blocks until the grace period has elapsed or confirmed is
+// closed, whichever comes first.
+func waitForRevocation(ctx context.Context, gracePeriod time.Duration, confirmed <-chan struct{}) error {
+	if gracePeriod <= 0 && confirmed == nil {
+		return nil
+	}
+	var timeout <-chan time.Time
+	if gracePeriod > 0 {
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-timeout:
+	case <-confirmed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package sams
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/background"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+const defaultServiceAccessTokensSweeperInterval = time.Hour
+
+// ServiceAccessTokenPolicy is the policy that service access tokens must comply
+// with.
+type ServiceAccessTokenPolicy struct {
+	// MaxAge is the maximum duration since the creation of a token (optional, 0
+	// disables the check).
+	MaxAge time.Duration
+	// RequireExpiry requires tokens to have an expiry time set.
+	RequireExpiry bool
+}
+
+// Violations returns the reasons the token violates the policy at the given
+// time, or nil if it complies.
+func (p ServiceAccessTokenPolicy) Violations(token *clientsv1.ServiceAccessToken, now time.Time) []string {
+	var reasons []string
+	if p.MaxAge > 0 && token.GetCreationTime() != nil {
+		if age := now.Sub(token.GetCreationTime().AsTime()); age > p.MaxAge {
+			reasons = append(reasons, "exceeds maximum age of "+p.MaxAge.String())
+		}
+	}
+	if p.RequireExpiry && token.GetExpireTime() == nil {
+		reasons = append(reasons, "has no expiry")
+	}
+	return reasons
+}
+
+func (p ServiceAccessTokenPolicy) validate() error {
+	if p.MaxAge < 0 {
+		return errors.New("MaxAge cannot be negative")
+	}
+	if p.MaxAge == 0 && !p.RequireExpiry {
+		return errors.New("policy has no checks")
+	}
+	return nil
+}
+
+// ServiceAccessTokenPolicyAction is the action to take on service access
+// tokens that violate the policy.
+type ServiceAccessTokenPolicyAction string
+
+const (
+	// ServiceAccessTokenPolicyReport only reports violating tokens.
+	ServiceAccessTokenPolicyReport ServiceAccessTokenPolicyAction = "report"
+	// ServiceAccessTokenPolicyRevoke revokes violating tokens.
+	ServiceAccessTokenPolicyRevoke ServiceAccessTokenPolicyAction = "revoke"
+)
+
+// ServiceAccessTokenViolation is a service access token that violates the
+// policy.
+type ServiceAccessTokenViolation struct {
+	Token *clientsv1.ServiceAccessToken
+	// Reasons are the reasons the token violates the policy.
+	Reasons []string
+	// Revoked is true if the token has been revoked.
+	Revoked bool
+}
+
+// ServiceAccessTokensSweeperOptions configures NewServiceAccessTokensSweeper.
+type ServiceAccessTokensSweeperOptions struct {
+	// Service is the service whose tokens are swept.
+	Service services.Service
+	// Policy is the policy that tokens must comply with.
+	Policy ServiceAccessTokenPolicy
+	// Action is the action to take on violating tokens.
+	//
+	// The default is ServiceAccessTokenPolicyReport.
+	Action ServiceAccessTokenPolicyAction
+	// DryRun only logs the violating tokens that would be revoked, without
+	// revoking them.
+	DryRun bool
+	// OnViolation is called for each violating token after the action has been
+	// taken, e.g. to notify the owner of the token (optional).
+	OnViolation func(ctx context.Context, violation ServiceAccessTokenViolation)
+	// Interval is the duration between sweeps.
+	//
+	// The default of 0 (or less) uses 1 hour.
+	Interval time.Duration
+}
+
+func (opts ServiceAccessTokensSweeperOptions) Validate() error {
+	if opts.Service == "" {
+		return errors.New("Service is required")
+	}
+	if err := opts.Policy.validate(); err != nil {
+		return errors.Wrap(err, "invalid Policy")
+	}
+	switch opts.Action {
+	case "", ServiceAccessTokenPolicyReport, ServiceAccessTokenPolicyRevoke:
+	default:
+		return errors.Newf("unknown Action %q", opts.Action)
+	}
+	return nil
+}
+
+// NewServiceAccessTokensSweeper returns a background routine that periodically
+// lists the service access tokens of a service, and reports or revokes the
+// tokens that violate the policy. Every action is logged.
+//
+// Required scopes: sams::service_access_tokens::read, and
+// sams::service_access_tokens::delete to revoke tokens
+func NewServiceAccessTokensSweeper(logger log.Logger, tokensService *ServiceAccessTokensServiceV1, opts ServiceAccessTokensSweeperOptions) (background.Routine, error) {
+	if tokensService == nil {
+		return nil, errors.New("service access tokens service is required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid options")
+	}
+	if opts.Action == "" {
+		opts.Action = ServiceAccessTokenPolicyReport
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultServiceAccessTokensSweeperInterval
+	}
+	logger = logger.Scoped("serviceAccessTokensSweeper").
+		With(
+			log.String("service", string(opts.Service)),
+			log.String("action", string(opts.Action)),
+			log.Bool("dryRun", opts.DryRun),
+		)
+	return &serviceAccessTokensSweeper{
+		logger: logger,
+		tokens: tokensService,
+		opts:   opts,
+		runner: newRoutineRunner(logger),
+	}, nil
+}
+
+type serviceAccessTokensSweeper struct {
+	logger log.Logger
+	tokens *ServiceAccessTokensServiceV1
+	opts   ServiceAccessTokensSweeperOptions
+
+	runner *routineRunner
+}
+
+func (s *serviceAccessTokensSweeper) Name() string {
+	return "SAMS Service Access Tokens Sweeper"
+}
+
+func (s *serviceAccessTokensSweeper) Start() {
+	s.runner.run("service access tokens sweeper", s.loop)
+}
+
+func (s *serviceAccessTokensSweeper) loop(ctx context.Context) {
+	s.logger.Info("started", log.Duration("interval", s.opts.Interval))
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.sweep(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("failed to sweep service access tokens", log.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep checks all tokens of the service once, and takes the action on the
+// violating tokens. It continues past failures to revoke individual tokens, and
+// returns all violations found.
+//
+// All tokens are listed before any token is revoked, as revoking tokens while
+// paginating would shift the tokens on later pages and skip some of them.
+func (s *serviceAccessTokensSweeper) sweep(ctx context.Context) ([]ServiceAccessTokenViolation, error) {
+	now := time.Now()
+	var (
+		checked    int
+		violations []ServiceAccessTokenViolation
+		errs       error
+	)
+	for token, err := range s.tokens.All(ctx, ListServiceAccessTokensOptions{Service: string(s.opts.Service)}) {
+		if err != nil {
+			// Still take the action on the violations found so far, so that a
+			// persistent failure on a later page does not block the sweep.
+			errs = errors.Wrap(err, "list tokens")
+			break
+		}
+		checked++
+		if reasons := s.opts.Policy.Violations(token, now); len(reasons) > 0 {
+			violations = append(violations, ServiceAccessTokenViolation{Token: token, Reasons: reasons})
+		}
+	}
+
+	for i := range violations {
+		violation := &violations[i]
+		logger := s.logger.With(
+			log.String("tokenID", violation.Token.GetId()),
+			log.String("userID", violation.Token.GetUserId()),
+			log.String("displayName", violation.Token.GetDisplayName()),
+			log.Strings("reasons", violation.Reasons),
+		)
+		switch {
+		case s.opts.Action != ServiceAccessTokenPolicyRevoke:
+			logger.Warn("service access token violates policy")
+		case s.opts.DryRun:
+			logger.Info("would revoke service access token that violates policy")
+		default:
+			err := s.tokens.RevokeServiceAccessToken(ctx, violation.Token.GetId())
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				logger.Error("failed to revoke service access token that violates policy", log.Error(err))
+				errs = errors.Append(errs, errors.Wrapf(err, "revoke token %q", violation.Token.GetId()))
+			} else {
+				violation.Revoked = true
+				logger.Info("revoked service access token that violates policy")
+			}
+		}
+		if s.opts.OnViolation != nil {
+			s.opts.OnViolation(ctx, *violation)
+		}
+	}
+
+	s.logger.Info("swept service access tokens",
+		log.Int("checked", checked),
+		log.Int("violations", len(violations)),
+		log.Duration("duration", time.Since(now)))
+	return violations, errs
+}
+
+func (s *serviceAccessTokensSweeper) Stop(ctx context.Context) error {
+	return s.runner.stop(ctx)
+}
@@ -0,0 +1,198 @@
+package sams
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+func TestServiceAccessTokenPolicy(t *testing.T) {
+	now := time.Now()
+	policy := ServiceAccessTokenPolicy{MaxAge: 24 * time.Hour, RequireExpiry: true}
+
+	assert.Empty(t, policy.Violations(&clientsv1.ServiceAccessToken{
+		CreationTime: timestamppb.New(now.Add(-time.Hour)),
+		ExpireTime:   timestamppb.New(now.Add(time.Hour)),
+	}, now))
+	autogold.Expect([]string{"exceeds maximum age of 24h0m0s", "has no expiry"}).Equal(t, policy.Violations(&clientsv1.ServiceAccessToken{
+		CreationTime: timestamppb.New(now.Add(-48 * time.Hour)),
+	}, now))
+}
+
+func TestServiceAccessTokensSweeper(t *testing.T) {
+	now := time.Now()
+	newFakeService := func() *fakeServiceAccessTokensService {
+		return &fakeServiceAccessTokensService{tokens: []*clientsv1.ServiceAccessToken{{
+			Id:           "compliant",
+			Service:      "analytics",
+			CreationTime: timestamppb.New(now.Add(-time.Hour)),
+			ExpireTime:   timestamppb.New(now.Add(time.Hour)),
+		}, {
+			Id:           "too-old",
+			Service:      "analytics",
+			CreationTime: timestamppb.New(now.Add(-48 * time.Hour)),
+			ExpireTime:   timestamppb.New(now.Add(time.Hour)),
+		}, {
+			Id:           "no-expiry",
+			Service:      "analytics",
+			CreationTime: timestamppb.New(now.Add(-time.Hour)),
+		}, {
+			Id:           "other-service",
+			Service:      "dotcom",
+			CreationTime: timestamppb.New(now.Add(-48 * time.Hour)),
+		}}}
+	}
+	newSweeper := func(t *testing.T, svc *fakeServiceAccessTokensService, opts ServiceAccessTokensSweeperOptions) (*serviceAccessTokensSweeper, func() []string) {
+		logger, exportLogs := logtest.Captured(t)
+		opts.Service = "analytics"
+		opts.Policy = ServiceAccessTokenPolicy{MaxAge: 24 * time.Hour, RequireExpiry: true}
+		routine, err := NewServiceAccessTokensSweeper(logger, newTestServiceAccessTokensService(t, svc), opts)
+		require.NoError(t, err)
+		return routine.(*serviceAccessTokensSweeper), func() []string { return exportLogs().Messages() }
+	}
+	violationIDs := func(violations []ServiceAccessTokenViolation) map[string]bool {
+		ids := make(map[string]bool)
+		for _, v := range violations {
+			ids[v.Token.GetId()] = v.Revoked
+		}
+		return ids
+	}
+
+	t.Run("report", func(t *testing.T) {
+		svc := newFakeService()
+		var reported []string
+		s, logs := newSweeper(t, svc, ServiceAccessTokensSweeperOptions{
+			OnViolation: func(_ context.Context, v ServiceAccessTokenViolation) {
+				reported = append(reported, v.Token.GetId())
+			},
+		})
+		violations, err := s.sweep(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"too-old": false, "no-expiry": false}, violationIDs(violations))
+		assert.Equal(t, []string{"too-old", "no-expiry"}, reported)
+		assert.Empty(t, svc.revoked)
+		autogold.Expect([]string{
+			"service access token violates policy", "service access token violates policy",
+			"swept service access tokens",
+		}).Equal(t, logs())
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		svc := newFakeService()
+		s, logs := newSweeper(t, svc, ServiceAccessTokensSweeperOptions{Action: ServiceAccessTokenPolicyRevoke})
+		violations, err := s.sweep(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"too-old": true, "no-expiry": true}, violationIDs(violations))
+		assert.Equal(t, []string{"too-old", "no-expiry"}, svc.revoked)
+		autogold.Expect([]string{
+			"revoked service access token that violates policy",
+			"revoked service access token that violates policy",
+			"swept service access tokens",
+		}).Equal(t, logs())
+	})
+
+	t.Run("revoke across pages", func(t *testing.T) {
+		svc := newFakeService()
+		for i := range 5 {
+			svc.tokens = append(svc.tokens, &clientsv1.ServiceAccessToken{
+				Id:           fmt.Sprintf("too-old-%d", i),
+				Service:      "analytics",
+				CreationTime: timestamppb.New(now.Add(-48 * time.Hour)),
+				ExpireTime:   timestamppb.New(now.Add(time.Hour)),
+			})
+		}
+		s, _ := newSweeper(t, svc, ServiceAccessTokensSweeperOptions{Action: ServiceAccessTokenPolicyRevoke})
+		violations, err := s.sweep(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, violations, 7)
+		assert.Equal(t, []string{
+			"too-old", "no-expiry",
+			"too-old-0", "too-old-1", "too-old-2", "too-old-3", "too-old-4",
+		}, svc.revoked)
+		// Pages of 2 tokens were listed before anything was revoked.
+		assert.Len(t, svc.listRequests, 4)
+	})
+
+	t.Run("revoke dry run", func(t *testing.T) {
+		svc := newFakeService()
+		s, logs := newSweeper(t, svc, ServiceAccessTokensSweeperOptions{Action: ServiceAccessTokenPolicyRevoke, DryRun: true})
+		violations, err := s.sweep(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"too-old": false, "no-expiry": false}, violationIDs(violations))
+		assert.Empty(t, svc.revoked)
+		autogold.Expect([]string{
+			"would revoke service access token that violates policy",
+			"would revoke service access token that violates policy",
+			"swept service access tokens",
+		}).Equal(t, logs())
+	})
+
+	t.Run("start and stop", func(t *testing.T) {
+		svc := newFakeService()
+		s, _ := newSweeper(t, svc, ServiceAccessTokensSweeperOptions{Interval: time.Hour})
+		go s.Start()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, s.Stop(ctx))
+	})
+
+	t.Run("stop before start", func(t *testing.T) {
+		svc := newFakeService()
+		s, _ := newSweeper(t, svc, ServiceAccessTokensSweeperOptions{Interval: time.Hour})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, s.Stop(ctx))
+
+		started := make(chan struct{})
+		go func() {
+			s.Start()
+			close(started)
+		}()
+		select {
+		case <-started:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for Start to return")
+		}
+		assert.Empty(t, svc.listRequests)
+	})
+}
+
+func TestServiceAccessTokensSweeperOptionsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		opts      ServiceAccessTokensSweeperOptions
+		wantError autogold.Value
+	}{{
+		name:      "no service",
+		opts:      ServiceAccessTokensSweeperOptions{Policy: ServiceAccessTokenPolicy{RequireExpiry: true}},
+		wantError: autogold.Expect("Service is required"),
+	}, {
+		name:      "empty policy",
+		opts:      ServiceAccessTokensSweeperOptions{Service: services.Dotcom},
+		wantError: autogold.Expect("invalid Policy: policy has no checks"),
+	}, {
+		name: "unknown action",
+		opts: ServiceAccessTokensSweeperOptions{
+			Service: services.Dotcom,
+			Policy:  ServiceAccessTokenPolicy{RequireExpiry: true},
+			Action:  "delete",
+		},
+		wantError: autogold.Expect(`unknown Action "delete"`),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			require.Error(t, err)
+			tc.wantError.Equal(t, err.Error())
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package sams
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+// fakeServiceAccessTokensService is an in-memory implementation of the
+// ServiceAccessTokensService.
+type fakeServiceAccessTokensService struct {
+	clientsv1connect.UnimplementedServiceAccessTokensServiceHandler
+
+	tokens []*clientsv1.ServiceAccessToken
+	// samePageToken makes ListServiceAccessTokens always return the requested
+	// page token as the next page token.
+	samePageToken bool
+
+	listRequests []*clientsv1.ListServiceAccessTokensRequest
+	revoked      []string
+}
+
+func (s *fakeServiceAccessTokensService) CreateServiceAccessToken(_ context.Context, req *connect.Request[clientsv1.CreateServiceAccessTokenRequest]) (*connect.Response[clientsv1.CreateServiceAccessTokenResponse], error) {
+	token := proto.Clone(req.Msg.GetToken()).(*clientsv1.ServiceAccessToken)
+	token.Id = fmt.Sprintf("token-%d", len(s.tokens))
+	token.CreationTime = timestamppb.Now()
+	s.tokens = append(s.tokens, token)
+	return connect.NewResponse(&clientsv1.CreateServiceAccessTokenResponse{
+		Token:  token,
+		Secret: "sams_sat_" + token.Id,
+	}), nil
+}
+
+func (s *fakeServiceAccessTokensService) RevokeServiceAccessToken(_ context.Context, req *connect.Request[clientsv1.RevokeServiceAccessTokenRequest]) (*connect.Response[clientsv1.RevokeServiceAccessTokenResponse], error) {
+	s.revoked = append(s.revoked, req.Msg.GetId())
+	s.tokens = slices.DeleteFunc(s.tokens, func(token *clientsv1.ServiceAccessToken) bool {
+		return token.GetId() == req.Msg.GetId()
+	})
+	return connect.NewResponse(&clientsv1.RevokeServiceAccessTokenResponse{}), nil
+}
+
+func (s *fakeServiceAccessTokensService) ListServiceAccessTokens(_ context.Context, req *connect.Request[clientsv1.ListServiceAccessTokensRequest]) (*connect.Response[clientsv1.ListServiceAccessTokensResponse], error) {
+	s.listRequests = append(s.listRequests, req.Msg)
+
+	var service string
+	for _, f := range req.Msg.GetFilters() {
+		if f.GetService() != "" {
+			service = f.GetService()
+		}
+	}
+	var matched []*clientsv1.ServiceAccessToken
+	for _, token := range s.tokens {
+		if service == "" || token.GetService() == service {
+			matched = append(matched, token)
+		}
+	}
+
+	offset := 0
+	if req.Msg.GetPageToken() != "" {
+		var err error
+		offset, err = strconv.Atoi(req.Msg.GetPageToken())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 2
+	}
+	end := min(offset+pageSize, len(matched))
+	resp := &clientsv1.ListServiceAccessTokensResponse{Tokens: matched[offset:end]}
+	if end < len(matched) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	if s.samePageToken {
+		resp.NextPageToken = req.Msg.GetPageToken()
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func newTestServiceAccessTokensService(t *testing.T, svc *fakeServiceAccessTokensService) *ServiceAccessTokensServiceV1 {
+	path, handler := clientsv1connect.NewServiceAccessTokensServiceHandler(svc)
+	mux := http.NewServeMux()
+	mux.Handle("/api/grpc"+path, http.StripPrefix("/api/grpc", handler))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClientV1(ClientV1Config{
+		ConnConfig:  ConnConfig{ExternalURL: srv.URL},
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "sams_at_test"}),
+	})
+	require.NoError(t, err)
+	return c.ServiceAccessTokens()
+}
+
+func testServiceAccessTokens(n int, service string) []*clientsv1.ServiceAccessToken {
+	tokens := make([]*clientsv1.ServiceAccessToken, n)
+	for i := range n {
+		tokens[i] = &clientsv1.ServiceAccessToken{
+			Id:      fmt.Sprintf("%s-%d", service, i),
+			Service: service,
+		}
+	}
+	return tokens
+}
+
+func tokenIDs(tokens []*clientsv1.ServiceAccessToken) []string {
+	ids := make([]string, len(tokens))
+	for i, token := range tokens {
+		ids[i] = token.GetId()
+	}
+	return ids
+}
+
+func TestListServiceAccessTokensAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all pages with filters", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{
+			tokens: append(testServiceAccessTokens(5, "dotcom"), testServiceAccessTokens(2, "ssc")...),
+		}
+		tokens, err := newTestServiceAccessTokensService(t, svc).ListAll(ctx, ListServiceAccessTokensOptions{Service: "dotcom"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dotcom-0", "dotcom-1", "dotcom-2", "dotcom-3", "dotcom-4"}, tokenIDs(tokens))
+
+		var pageTokens []string
+		for _, req := range svc.listRequests {
+			pageTokens = append(pageTokens, req.GetPageToken())
+			assert.Equal(t, "dotcom", req.GetFilters()[0].GetService())
+		}
+		assert.Equal(t, []string{"", "2", "4"}, pageTokens)
+	})
+
+	t.Run("single page", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{tokens: testServiceAccessTokens(5, "dotcom")}
+		tokens, nextPageToken, err := newTestServiceAccessTokensService(t, svc).ListServiceAccessTokensPage(ctx, ListServiceAccessTokensOptions{PageSize: 3})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dotcom-0", "dotcom-1", "dotcom-2"}, tokenIDs(tokens))
+		assert.Equal(t, "3", nextPageToken)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{tokens: testServiceAccessTokens(5, "dotcom")}
+		var ids []string
+		for token, err := range newTestServiceAccessTokensService(t, svc).All(ctx, ListServiceAccessTokensOptions{}) {
+			require.NoError(t, err)
+			ids = append(ids, token.GetId())
+			if len(ids) == 3 {
+				break
+			}
+		}
+		assert.Equal(t, []string{"dotcom-0", "dotcom-1", "dotcom-2"}, ids)
+		assert.Len(t, svc.listRequests, 2)
+	})
+
+	t.Run("same page token", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{
+			tokens:        testServiceAccessTokens(5, "dotcom"),
+			samePageToken: true,
+		}
+		_, err := newTestServiceAccessTokensService(t, svc).ListAll(ctx, ListServiceAccessTokensOptions{PageToken: "2"})
+		assert.EqualError(t, err, `server returned the same page token "2"`)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{}
+		_, err := newTestServiceAccessTokensService(t, svc).ListAll(ctx, ListServiceAccessTokensOptions{PageToken: "invalid"})
+		assert.Error(t, err)
+	})
+}
+
+func TestRotateServiceAccessToken(t *testing.T) {
+	ctx := context.Background()
+	old := &clientsv1.ServiceAccessToken{
+		Id:           "old",
+		Service:      "analytics",
+		Scopes:       []string{"analytics::analytics::read"},
+		UserId:       "user-1",
+		DisplayName:  "Analytics exporter",
+		CreationTime: timestamppb.New(time.Now().Add(-time.Hour)),
+		ExpireTime:   timestamppb.New(time.Now().Add(23 * time.Hour)),
+	}
+
+	t.Run("revoke immediately", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{}
+		var published string
+		resp, err := newTestServiceAccessTokensService(t, svc).RotateServiceAccessToken(ctx, old, RotateServiceAccessTokenOptions{
+			Publish: func(_ context.Context, replacement *CreateServiceAccessTokenResponse) error {
+				published = replacement.Secret.Reveal()
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.OldTokenRevoked)
+		assert.Equal(t, "sams_sat_token-0", published)
+		assert.Equal(t, []string{"old"}, svc.revoked)
+
+		// The replacement has the same attributes and lifetime.
+		replacement := svc.tokens[0]
+		assert.Equal(t, old.Service, replacement.Service)
+		assert.Equal(t, old.Scopes, replacement.Scopes)
+		assert.Equal(t, old.UserId, replacement.UserId)
+		assert.Equal(t, old.DisplayName, replacement.DisplayName)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), replacement.ExpireTime.AsTime(), time.Minute)
+	})
+
+	t.Run("revoke on confirmation", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{}
+		confirmed := make(chan struct{})
+		resp, err := newTestServiceAccessTokensService(t, svc).RotateServiceAccessToken(ctx, old, RotateServiceAccessTokenOptions{
+			Publish: func(context.Context, *CreateServiceAccessTokenResponse) error {
+				close(confirmed)
+				return nil
+			},
+			GracePeriod: time.Hour,
+			Confirmed:   confirmed,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.OldTokenRevoked)
+		assert.Equal(t, []string{"old"}, svc.revoked)
+	})
+
+	t.Run("canceled during grace period", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{}
+		ctx, cancel := context.WithCancel(ctx)
+		resp, err := newTestServiceAccessTokensService(t, svc).RotateServiceAccessToken(ctx, old, RotateServiceAccessTokenOptions{
+			Publish: func(context.Context, *CreateServiceAccessTokenResponse) error {
+				cancel()
+				return nil
+			},
+			GracePeriod: time.Hour,
+		})
+		assert.EqualError(t, err, `old token "old" was not revoked: context canceled`)
+		require.NotNil(t, resp)
+		assert.False(t, resp.OldTokenRevoked)
+		assert.Equal(t, "sams_sat_token-0", resp.Replacement.Secret.Reveal())
+		assert.Empty(t, svc.revoked)
+	})
+
+	t.Run("inherited lifetime is never in the past", func(t *testing.T) {
+		malformed := proto.Clone(old).(*clientsv1.ServiceAccessToken)
+		malformed.ExpireTime = timestamppb.New(malformed.CreationTime.AsTime().Add(-time.Minute))
+
+		svc := &fakeServiceAccessTokensService{}
+		_, err := newTestServiceAccessTokensService(t, svc).RotateServiceAccessToken(ctx, malformed, RotateServiceAccessTokenOptions{
+			Publish: func(context.Context, *CreateServiceAccessTokenResponse) error { return nil },
+		})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(minRotatedServiceAccessTokenLifetime), svc.tokens[0].ExpireTime.AsTime(), time.Minute)
+	})
+
+	t.Run("expires in the past", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{}
+		expiresAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err := newTestServiceAccessTokensService(t, svc).RotateServiceAccessToken(ctx, old, RotateServiceAccessTokenOptions{
+			Publish:   func(context.Context, *CreateServiceAccessTokenResponse) error { return nil },
+			ExpiresAt: &expiresAt,
+		})
+		assert.EqualError(t, err, "ExpiresAt 2020-01-01T00:00:00Z is not in the future")
+		assert.Empty(t, svc.tokens)
+		assert.Empty(t, svc.revoked)
+	})
+
+	t.Run("scopes no longer allowed", func(t *testing.T) {
+		outdated := proto.Clone(old).(*clientsv1.ServiceAccessToken)
+		outdated.Scopes = []string{"analytics::not_a_permission::read"}
+
+		svc := &fakeServiceAccessTokensService{}
+		_, err := newTestServiceAccessTokensService(t, svc).RotateServiceAccessToken(ctx, outdated, RotateServiceAccessTokenOptions{
+			Publish: func(context.Context, *CreateServiceAccessTokenResponse) error { return nil },
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `old token "old" has scopes that are no longer allowed`)
+		assert.Empty(t, svc.tokens)
+		assert.Empty(t, svc.revoked)
+	})
+
+	t.Run("publish failed", func(t *testing.T) {
+		svc := &fakeServiceAccessTokensService{}
+		_, err := newTestServiceAccessTokensService(t, svc).RotateServiceAccessToken(ctx, old, RotateServiceAccessTokenOptions{
+			Publish: func(context.Context, *CreateServiceAccessTokenResponse) error {
+				return errors.New("secret manager unavailable")
+			},
+		})
+		assert.EqualError(t, err, "publish replacement token: secret manager unavailable")
+		// Only the replacement is revoked.
+		assert.Equal(t, []string{"token-0"}, svc.revoked)
+	})
+}
+
+func TestCreateServiceAccessTokenScopes(t *testing.T) {
+	ctx := context.Background()
+	svc := &fakeServiceAccessTokensService{}
+	tokens := newTestServiceAccessTokensService(t, svc)
+
+	_, err := tokens.CreateServiceAccessToken(ctx, services.Analytics, []scopes.Scope{
+		"analytics::analytics::read",
+		"analytics",
+		"analytics::not_a_permission::read",
+		"telemetry_gateway::events::write",
+		scopes.OpenID,
+	}, "user-1", CreateServiceAccessTokenOptions{})
+	require.Error(t, err)
+	for _, want := range []string{
+		`scope "analytics" is malformed`,
+		`scope "analytics::not_a_permission::read" is not allowed`,
+		`scope "telemetry_gateway::events::write" does not belong to service "analytics"`,
+		`scope "openid" does not belong to service "analytics"`,
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+	assert.NotContains(t, err.Error(), `"analytics::analytics::read"`)
+	assert.Empty(t, svc.tokens, "must not call SAMS")
+
+	resp, err := tokens.CreateServiceAccessToken(ctx, services.Analytics, []scopes.Scope{"analytics::analytics::read"}, "user-1", CreateServiceAccessTokenOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"analytics::analytics::read"}, resp.Token.GetScopes())
+}
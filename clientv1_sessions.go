@@ -8,7 +8,6 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 
-	"github.com/hashicorp/golang-lru/v2/expirable"
 	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
 )
@@ -18,7 +17,7 @@ import (
 type SessionsServiceV1 struct {
 	client *ClientV1
 	// sessionsCache may be nil if not enabled.
-	sessionsCache *expirable.LRU[string, *clientsv1.Session]
+	sessionsCache *evictableLRU[string, *clientsv1.Session]
 }
 
 func (s *SessionsServiceV1) newClient(ctx context.Context) clientsv1connect.SessionsServiceClient {
@@ -61,6 +61,20 @@ func TestParseResponseAndError(t *testing.T) {
 			},
 			wantErr: ErrRecordMismatch.Error(),
 		},
+		{
+			name: "removal threshold exceeded",
+			err: func() error {
+				detail, err := connect.NewErrorDetail(&clientsv1.ErrorRemovalThresholdExceeded{
+					ExistingResourceCount: 10,
+					RemovedResourceCount:  6,
+				})
+				require.NoError(t, err)
+				grpcErr := connect.NewError(connect.CodeFailedPrecondition, nil)
+				grpcErr.AddDetail(detail)
+				return grpcErr
+			},
+			wantErr: "6 of 10 existing resources would be removed: removal threshold exceeded",
+		},
 		{
 			name: "other error",
 			err: func() error {
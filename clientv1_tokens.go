@@ -2,6 +2,8 @@ package sams
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"connectrpc.com/connect"
@@ -9,7 +11,6 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 
-	"github.com/hashicorp/golang-lru/v2/expirable"
 	clientsv1 "github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/clients/v1/clientsv1connect"
 	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
@@ -20,7 +21,7 @@ import (
 type TokensServiceV1 struct {
 	client *ClientV1
 	// introspectTokenCache may be nil if not enabled.
-	introspectTokenCache *expirable.LRU[string, *IntrospectTokenResponse]
+	introspectTokenCache *tokenIntrospectionCache
 }
 
 func (s *TokensServiceV1) newClient(ctx context.Context) clientsv1connect.TokensServiceClient {
@@ -54,9 +55,7 @@ type IntrospectTokenResponse struct {
 // `.Active == false`.
 func (s *TokensServiceV1) IntrospectToken(ctx context.Context, token string) (*IntrospectTokenResponse, error) {
 	if s.introspectTokenCache != nil {
-		if cached, ok := s.introspectTokenCache.Get(token); ok && // entry exists
-			// and NOT expired
-			cached.ExpiresAt.After(time.Now()) {
+		if cached, ok := s.introspectTokenCache.get(token); ok {
 			trace.SpanFromContext(ctx).
 				SetAttributes(attribute.Bool("sams.introspectToken.fromCache", true))
 			return cached, nil
@@ -73,14 +72,86 @@ func (s *TokensServiceV1) IntrospectToken(ctx context.Context, token string) (*I
 	}
 
 	tokenResponse := &IntrospectTokenResponse{
-		Active:    resp.Msg.Active,
-		Scopes:    scopes.ToScopes(resp.Msg.Scopes),
-		ClientID:  resp.Msg.ClientId,
-		ExpiresAt: resp.Msg.ExpiresAt.AsTime(),
-		UserID:    resp.Msg.UserId,
+		Active:   resp.Msg.Active,
+		Scopes:   scopes.ToScopes(resp.Msg.Scopes),
+		ClientID: resp.Msg.ClientId,
+		UserID:   resp.Msg.UserId,
 	}
-	if s.introspectTokenCache != nil && tokenResponse.ExpiresAt.After(time.Now()) {
-		_ = s.introspectTokenCache.Add(token, tokenResponse)
+	// A nil timestamp would otherwise be converted to the Unix epoch.
+	if resp.Msg.ExpiresAt != nil {
+		tokenResponse.ExpiresAt = resp.Msg.ExpiresAt.AsTime()
+	}
+	if s.introspectTokenCache != nil {
+		s.introspectTokenCache.add(token, tokenResponse)
 	}
 	return tokenResponse, nil
 }
+
+// tokenIntrospectionCache caches token introspection results in memory. Tokens
+// are never used as keys directly, only their SHA-256 hashes are.
+type tokenIntrospectionCache struct {
+	// lru is bounded by the longest of the TTLs, every entry also carries its own
+	// expiry.
+	lru *evictableLRU[string, *tokenIntrospectionCacheEntry]
+	// ttl is the maximum duration to cache an active token.
+	ttl time.Duration
+	// inactiveTTL is the duration to cache an inactive token, caching of inactive
+	// tokens is disabled if it is not positive.
+	inactiveTTL time.Duration
+}
+
+type tokenIntrospectionCacheEntry struct {
+	result     *IntrospectTokenResponse
+	validUntil time.Time
+}
+
+func newTokenIntrospectionCache(size int, ttl, inactiveTTL time.Duration) *tokenIntrospectionCache {
+	return &tokenIntrospectionCache{
+		lru:         newEvictableLRU[string, *tokenIntrospectionCacheEntry](size, max(ttl, inactiveTTL)),
+		ttl:         ttl,
+		inactiveTTL: inactiveTTL,
+	}
+}
+
+func tokenIntrospectionCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached result of the token, if present and not yet expired.
+func (c *tokenIntrospectionCache) get(token string) (*IntrospectTokenResponse, bool) {
+	entry, ok := c.lru.Get(tokenIntrospectionCacheKey(token))
+	if !ok || !time.Now().Before(entry.validUntil) {
+		return nil, false
+	}
+	return entry.result, true
+}
+
+// add caches the result of the token for the duration allowed by its state.
+func (c *tokenIntrospectionCache) add(token string, result *IntrospectTokenResponse) {
+	now := time.Now()
+	var validUntil time.Time
+	if result.Active {
+		validUntil = now.Add(c.ttl)
+		if !result.ExpiresAt.IsZero() && result.ExpiresAt.Before(validUntil) {
+			validUntil = result.ExpiresAt
+		}
+	} else {
+		validUntil = now.Add(c.inactiveTTL)
+	}
+	if !now.Before(validUntil) {
+		return // nothing worth caching
+	}
+	_ = c.lru.Add(tokenIntrospectionCacheKey(token), &tokenIntrospectionCacheEntry{
+		result:     result,
+		validUntil: validUntil,
+	})
+}
+
+// removeUser removes all cached results of tokens issued to the user with the
+// given ID.
+func (c *tokenIntrospectionCache) removeUser(userID string) {
+	c.lru.removeFunc(func(_ string, entry *tokenIntrospectionCacheEntry) bool {
+		return entry.result.UserID == userID
+	})
+}
@@ -0,0 +1,104 @@
+package sams
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenIntrospectionCache(t *testing.T) {
+	const ttl = time.Minute
+	for _, tc := range []struct {
+		name        string
+		inactiveTTL time.Duration
+		result      *IntrospectTokenResponse
+		wantCached  bool
+		// wantValidFor is the expected remaining validity of the entry.
+		wantValidFor time.Duration
+	}{{
+		name: "active token expiring after TTL",
+		result: &IntrospectTokenResponse{
+			Active:    true,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+		wantCached:   true,
+		wantValidFor: ttl,
+	}, {
+		name: "active token expiring before TTL",
+		result: &IntrospectTokenResponse{
+			Active:    true,
+			ExpiresAt: time.Now().Add(10 * time.Second),
+		},
+		wantCached:   true,
+		wantValidFor: 10 * time.Second,
+	}, {
+		name: "active token without expiry",
+		result: &IntrospectTokenResponse{
+			Active: true,
+		},
+		wantCached:   true,
+		wantValidFor: ttl,
+	}, {
+		name: "active token already expired",
+		result: &IntrospectTokenResponse{
+			Active:    true,
+			ExpiresAt: time.Now().Add(-time.Second),
+		},
+		wantCached: false,
+	}, {
+		name:        "inactive token",
+		inactiveTTL: 5 * time.Second,
+		result: &IntrospectTokenResponse{
+			Active: false,
+		},
+		wantCached:   true,
+		wantValidFor: 5 * time.Second,
+	}, {
+		name:        "inactive token with negative caching disabled",
+		inactiveTTL: -1,
+		result: &IntrospectTokenResponse{
+			Active: false,
+		},
+		wantCached: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTokenIntrospectionCache(10, ttl, tc.inactiveTTL)
+			c.add("sams_at_foobar", tc.result)
+
+			got, ok := c.get("sams_at_foobar")
+			require.Equal(t, tc.wantCached, ok)
+			if !tc.wantCached {
+				return
+			}
+			assert.Same(t, tc.result, got)
+
+			entry, ok := c.lru.Peek(tokenIntrospectionCacheKey("sams_at_foobar"))
+			require.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(tc.wantValidFor), entry.validUntil, time.Second)
+		})
+	}
+
+	t.Run("keys are hashed", func(t *testing.T) {
+		c := newTokenIntrospectionCache(10, ttl, 0)
+		c.add("sams_at_foobar", &IntrospectTokenResponse{Active: true})
+		for _, key := range c.lru.Keys() {
+			assert.NotContains(t, key, "foobar")
+		}
+	})
+
+	t.Run("entries expire", func(t *testing.T) {
+		c := newTokenIntrospectionCache(10, ttl, 0)
+		c.add("sams_at_foobar", &IntrospectTokenResponse{
+			Active:    true,
+			ExpiresAt: time.Now().Add(50 * time.Millisecond),
+		})
+		_, ok := c.get("sams_at_foobar")
+		require.True(t, ok)
+
+		time.Sleep(100 * time.Millisecond)
+		_, ok = c.get("sams_at_foobar")
+		assert.False(t, ok)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -16,6 +18,14 @@ import (
 // v1.
 type UsersServiceV1 struct {
 	client *ClientV1
+	// userRolesCache may be nil if not enabled.
+	userRolesCache *evictableLRU[userRolesCacheKey, []*clientsv1.Role]
+}
+
+// userRolesCacheKey identifies the roles of a user scoped by a service.
+type userRolesCacheKey struct {
+	userID  string
+	service string
 }
 
 func (s *UsersServiceV1) newClient(ctx context.Context) clientsv1connect.UsersServiceClient {
@@ -91,6 +101,17 @@ func (s *UsersServiceV1) GetUsersByIDs(ctx context.Context, ids []string) ([]*cl
 //
 // Required scopes: sams::user.roles::read
 func (s *UsersServiceV1) GetUserRolesByID(ctx context.Context, userID, service string) ([]*clientsv1.Role, error) {
+	cacheKey := userRolesCacheKey{userID: userID, service: service}
+	if s.userRolesCache != nil {
+		if cached, ok := s.userRolesCache.Get(cacheKey); ok {
+			trace.SpanFromContext(ctx).
+				SetAttributes(attribute.Bool("sams.userRoles.fromCache", true))
+			return cached, nil
+		}
+	}
+	trace.SpanFromContext(ctx).
+		SetAttributes(attribute.Bool("sams.userRoles.fromCache", false))
+
 	req := &clientsv1.GetUserRolesRequest{
 		Id:      userID,
 		Service: service,
@@ -100,6 +121,9 @@ func (s *UsersServiceV1) GetUserRolesByID(ctx context.Context, userID, service s
 	if err != nil {
 		return nil, err
 	}
+	if s.userRolesCache != nil {
+		_ = s.userRolesCache.Add(cacheKey, resp.Msg.GetUserRoles())
+	}
 	return resp.Msg.GetUserRoles(), nil
 }
 
@@ -0,0 +1,51 @@
+// Package connectutil provides helpers shared by the ConnectRPC and HTTP
+// middlewares of this module.
+package connectutil
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InternalError logs an error, adds it to the trace, and returns a connect
+// error with a safe message.
+func InternalError(ctx context.Context, logger log.Logger, err error, safeMsg string) error {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("safe_msg", safeMsg),
+		attribute.String("full_error", err.Error()))
+	span.SetStatus(otelcodes.Error, err.Error())
+
+	logger = LoggerWithTrace(ctx, logger).AddCallerSkip(1)
+
+	// Log at different levels and return different codes depending on the type
+	// of this unexpected error.
+	if errors.Is(err, context.Canceled) {
+		code := connect.CodeCanceled
+		logger.Warn(safeMsg,
+			log.String("code", code.String()),
+			log.Error(err))
+		return connect.NewError(code, errors.New(safeMsg))
+	}
+	code := connect.CodeInternal
+	logger.Error(safeMsg,
+		log.String("code", code.String()),
+		log.Error(err))
+	return connect.NewError(code, errors.New(safeMsg))
+}
+
+// LoggerWithTrace returns the logger with the trace and span IDs of the span in
+// the context.
+func LoggerWithTrace(ctx context.Context, logger log.Logger) log.Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	return logger.WithTrace(log.TraceContext{
+		TraceID: spanContext.TraceID().String(),
+		SpanID:  spanContext.SpanID().String(),
+	})
+}
@@ -0,0 +1,151 @@
+package roles
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+// ParseRole parses a fully qualified role name, e.g. "dotcom::site_admin". It
+// returns an error if the role is malformed or not registered.
+func ParseRole(s string) (Role, error) {
+	service, name, ok := strings.Cut(s, "::")
+	if !ok || service == "" || !NameRegex.MatchString(name) {
+		return "", errors.Newf("malformed role %q, must be in the format of \"service::name\"", s)
+	}
+	role := ToRole(services.Service(service), name)
+	if !Contains(role) {
+		return "", errors.Newf("role %q is not registered", s)
+	}
+	return role, nil
+}
+
+// ParseRoles is like ParseRole for a list of roles, and returns an error for
+// all invalid roles.
+func ParseRoles(ss []string) ([]Role, error) {
+	roles := make([]Role, 0, len(ss))
+	var errs error
+	for _, s := range ss {
+		role, err := ParseRole(s)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		roles = append(roles, role)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return roles, nil
+}
+
+// RoleRef is a reference to a role, and the resource the role is held on if the
+// role is not a service level role, e.g. to be stored in databases and audit
+// logs.
+//
+// The canonical string encoding is the role for service level roles, e.g.
+// "dotcom::site_admin", and "role@resource_type:resource_id" otherwise, e.g.
+// "enterprise_portal::customer_admin@enterprise_subscription:es_123". Use
+// ParseRoleRef to parse it back.
+type RoleRef struct {
+	// Role is the fully qualified role name.
+	Role Role
+	// ResourceType is the resource type of Role, it MUST be Service for service
+	// level roles.
+	ResourceType ResourceType
+	// ResourceID is the ID of the resource the role is held on, it MUST be empty
+	// for service level roles.
+	ResourceID string
+}
+
+// NewRoleRef returns a validated reference to the role on the resource with the
+// given ID. The resource ID MUST be empty for service level roles.
+func NewRoleRef(role Role, resourceID string) (RoleRef, error) {
+	ref := RoleRef{
+		Role:         role,
+		ResourceType: role.ResourceType(),
+		ResourceID:   resourceID,
+	}
+	if err := ref.Validate(); err != nil {
+		return RoleRef{}, err
+	}
+	return ref, nil
+}
+
+// Validate returns an error if the role is not registered, or the resource does
+// not match the resource type of the role.
+func (r RoleRef) Validate() error {
+	if !Contains(r.Role) {
+		return errors.Newf("role %q is not registered", r.Role)
+	}
+	if want := r.Role.ResourceType(); r.ResourceType != want {
+		return errors.Newf("role %q is associated with resource type %q, got %q", r.Role, want, r.ResourceType)
+	}
+	if r.ResourceType.IsService() {
+		if r.ResourceID != "" {
+			return errors.Newf("service level role %q cannot have a resource ID", r.Role)
+		}
+		return nil
+	}
+	if r.ResourceID == "" {
+		return errors.Newf("role %q requires a resource ID", r.Role)
+	}
+	if !utf8.ValidString(r.ResourceID) {
+		return errors.New("resource ID must be valid UTF-8")
+	}
+	return nil
+}
+
+// String returns the canonical string encoding of the reference.
+func (r RoleRef) String() string {
+	if r.ResourceType.IsService() {
+		return string(r.Role)
+	}
+	return string(r.Role) + "@" + string(r.ResourceType) + ":" + r.ResourceID
+}
+
+// ParseRoleRef parses the canonical string encoding of a RoleRef, see
+// RoleRef.String. It returns an error if the reference is malformed or invalid.
+func ParseRoleRef(s string) (RoleRef, error) {
+	roleStr, resource, hasResource := strings.Cut(s, "@")
+	role, err := ParseRole(roleStr)
+	if err != nil {
+		return RoleRef{}, err
+	}
+	ref := RoleRef{Role: role, ResourceType: Service}
+	if hasResource {
+		// The resource type cannot contain ":", but the resource ID may.
+		resourceType, resourceID, ok := strings.Cut(resource, ":")
+		if !ok {
+			return RoleRef{}, errors.Newf("malformed role reference %q, must be in the format of \"role@resource_type:resource_id\"", s)
+		}
+		ref.ResourceType = ResourceType(resourceType)
+		ref.ResourceID = resourceID
+	}
+	if err = ref.Validate(); err != nil {
+		return RoleRef{}, err
+	}
+	return ref, nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical string
+// encoding.
+func (r RoleRef) MarshalText() ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseRoleRef.
+func (r *RoleRef) UnmarshalText(text []byte) error {
+	ref, err := ParseRoleRef(string(text))
+	if err != nil {
+		return err
+	}
+	*r = ref
+	return nil
+}
@@ -0,0 +1,147 @@
+package roles
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleService(t *testing.T) {
+	assert.Equal(t, "dotcom", string(RoleDotcomSiteAdmin.Service()))
+	assert.Equal(t, "site_admin", RoleDotcomSiteAdmin.Name())
+	// Must not panic on malformed roles.
+	assert.Empty(t, Role("site_admin").Service())
+	assert.Empty(t, Role("site_admin").Name())
+}
+
+func TestParseRole(t *testing.T) {
+	for _, tc := range []struct {
+		input     string
+		wantError autogold.Value
+	}{
+		{input: "dotcom::site_admin", wantError: autogold.Expect(nil)},
+		{input: "site_admin", wantError: autogold.Expect(`malformed role "site_admin", must be in the format of "service::name"`)},
+		{input: "::site_admin", wantError: autogold.Expect(`malformed role "::site_admin", must be in the format of "service::name"`)},
+		{input: "dotcom::", wantError: autogold.Expect(`malformed role "dotcom::", must be in the format of "service::name"`)},
+		{input: "dotcom::Site-Admin", wantError: autogold.Expect(`malformed role "dotcom::Site-Admin", must be in the format of "service::name"`)},
+		{input: "dotcom::not_a_role", wantError: autogold.Expect(`role "dotcom::not_a_role" is not registered`)},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			role, err := ParseRole(tc.input)
+			if err != nil {
+				tc.wantError.Equal(t, err.Error())
+				return
+			}
+			tc.wantError.Equal(t, nil)
+			assert.Equal(t, Role(tc.input), role)
+		})
+	}
+
+	t.Run("ParseRoles", func(t *testing.T) {
+		_, err := ParseRoles([]string{"dotcom::site_admin", "foo", "dotcom::not_a_role"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `malformed role "foo"`)
+		assert.Contains(t, err.Error(), `role "dotcom::not_a_role" is not registered`)
+
+		got, err := ParseRoles([]string{"dotcom::site_admin", "ssc::admin"})
+		require.NoError(t, err)
+		assert.Equal(t, []Role{RoleDotcomSiteAdmin, RoleSSCAdmin}, got)
+	})
+}
+
+func TestRoleRef(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		input     string
+		wantRef   RoleRef
+		wantError autogold.Value
+	}{{
+		name:  "service role",
+		input: "dotcom::site_admin",
+		wantRef: RoleRef{
+			Role:         RoleDotcomSiteAdmin,
+			ResourceType: Service,
+		},
+		wantError: autogold.Expect(nil),
+	}, {
+		name:  "resource role",
+		input: "enterprise_portal::customer_admin@enterprise_subscription:es_123",
+		wantRef: RoleRef{
+			Role:         RoleEnterprisePortalCustomerAdmin,
+			ResourceType: EnterpriseSubscription,
+			ResourceID:   "es_123",
+		},
+		wantError: autogold.Expect(nil),
+	}, {
+		name:  "resource ID with separators",
+		input: "enterprise_portal::customer_admin@enterprise_subscription:es:1@2",
+		wantRef: RoleRef{
+			Role:         RoleEnterprisePortalCustomerAdmin,
+			ResourceType: EnterpriseSubscription,
+			ResourceID:   "es:1@2",
+		},
+		wantError: autogold.Expect(nil),
+	}, {
+		name:      "service role with resource",
+		input:     "dotcom::site_admin@service:foo",
+		wantError: autogold.Expect(`service level role "dotcom::site_admin" cannot have a resource ID`),
+	}, {
+		name:      "resource role without resource",
+		input:     "enterprise_portal::customer_admin",
+		wantError: autogold.Expect(`role "enterprise_portal::customer_admin" is associated with resource type "enterprise_subscription", got "service"`),
+	}, {
+		name:      "resource role with empty resource ID",
+		input:     "enterprise_portal::customer_admin@enterprise_subscription:",
+		wantError: autogold.Expect(`role "enterprise_portal::customer_admin" requires a resource ID`),
+	}, {
+		name:      "mismatched resource type",
+		input:     "enterprise_portal::customer_admin@service:es_123",
+		wantError: autogold.Expect(`role "enterprise_portal::customer_admin" is associated with resource type "enterprise_subscription", got "service"`),
+	}, {
+		name:      "missing resource type separator",
+		input:     "enterprise_portal::customer_admin@es_123",
+		wantError: autogold.Expect(`malformed role reference "enterprise_portal::customer_admin@es_123", must be in the format of "role@resource_type:resource_id"`),
+	}, {
+		name:      "unregistered role",
+		input:     "enterprise_portal::not_a_role@enterprise_subscription:es_123",
+		wantError: autogold.Expect(`role "enterprise_portal::not_a_role" is not registered`),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := ParseRoleRef(tc.input)
+			if err != nil {
+				tc.wantError.Equal(t, err.Error())
+				return
+			}
+			tc.wantError.Equal(t, nil)
+			assert.Equal(t, tc.wantRef, ref)
+			// Round trip through the canonical encoding.
+			assert.Equal(t, tc.input, ref.String())
+		})
+	}
+
+	t.Run("NewRoleRef", func(t *testing.T) {
+		ref, err := NewRoleRef(RoleEnterprisePortalCustomerAdmin, "es_123")
+		require.NoError(t, err)
+		assert.Equal(t, "enterprise_portal::customer_admin@enterprise_subscription:es_123", ref.String())
+
+		_, err = NewRoleRef(RoleDotcomSiteAdmin, "es_123")
+		assert.EqualError(t, err, `service level role "dotcom::site_admin" cannot have a resource ID`)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		ref, err := NewRoleRef(RoleEnterprisePortalCustomerAdmin, "es_123")
+		require.NoError(t, err)
+		data, err := json.Marshal(map[string]RoleRef{"role": ref})
+		require.NoError(t, err)
+		autogold.Expect(`{"role":"enterprise_portal::customer_admin@enterprise_subscription:es_123"}`).Equal(t, string(data))
+
+		var got map[string]RoleRef
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, ref, got["role"])
+
+		assert.Error(t, json.Unmarshal([]byte(`{"role":"dotcom::not_a_role"}`), &got))
+	})
+}
@@ -0,0 +1,210 @@
+package roles
+
+import (
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+// The list of regular expressions to make sure each part of a role is
+// spec-compliant.
+var (
+	NameRegex         = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+	ResourceTypeRegex = regexp.MustCompile(`^[a-z][a-z_]{0,63}$`)
+)
+
+// registry is the registry of all known roles and resource types. Built-in
+// roles are registered during package initialization.
+var registry = &roleRegistry{
+	byID:          make(map[Role]roleInfo),
+	resourceTypes: []ResourceType{Service, EnterpriseSubscription},
+	implies:       make(map[Role][]Role),
+}
+
+type roleRegistry struct {
+	// mu protects the fields below.
+	mu sync.RWMutex
+	// roles is the list of registered roles, in the order of registration.
+	roles         []roleInfo
+	byID          map[Role]roleInfo
+	resourceTypes []ResourceType
+	// implies is the directed acyclic graph of implications, from a role to the
+	// roles it directly implies.
+	implies map[Role][]Role
+}
+
+// Register registers a role named name for the service, which is associated
+// with the resource type, and returns the fully qualified role name. It returns
+// an error if the name is malformed, the service or the resource type is
+// unknown, or the role is already registered.
+//
+// Services SHOULD register their roles during initialization, e.g. in package
+// level variables with MustRegister, so that the roles are available before
+// being used.
+func Register(service services.Service, name string, resourceType ResourceType) (Role, error) {
+	if !slices.Contains(services.List(), service) {
+		return "", errors.Newf("unknown service %q", service)
+	}
+	if !NameRegex.MatchString(name) {
+		return "", errors.Newf("invalid role name %q, must match %s", name, NameRegex)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if !slices.Contains(registry.resourceTypes, resourceType) {
+		return "", errors.Newf("unknown resource type %q", resourceType)
+	}
+	id := ToRole(service, name)
+	if _, ok := registry.byID[id]; ok {
+		return "", errors.Newf("role %q is already registered", id)
+	}
+	role := roleInfo{
+		id:           id,
+		service:      service,
+		resourceType: resourceType,
+	}
+	registry.roles = append(registry.roles, role)
+	registry.byID[id] = role
+	return id, nil
+}
+
+// MustRegister is like Register but panics if the role cannot be registered.
+func MustRegister(service services.Service, name string, resourceType ResourceType) Role {
+	role, err := Register(service, name, resourceType)
+	if err != nil {
+		panic(err)
+	}
+	return role
+}
+
+// RegisterResourceType registers a resource type that roles can be associated
+// with. It returns an error if the resource type is malformed, reserved, or
+// already registered.
+func RegisterResourceType(resourceType ResourceType) error {
+	if !ResourceTypeRegex.MatchString(string(resourceType)) {
+		return errors.Newf("invalid resource type %q, must match %s", resourceType, ResourceTypeRegex)
+	}
+	if resourceType == unknownResourceType {
+		return errors.Newf("resource type %q is reserved", resourceType)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if slices.Contains(registry.resourceTypes, resourceType) {
+		return errors.Newf("resource type %q is already registered", resourceType)
+	}
+	registry.resourceTypes = append(registry.resourceTypes, resourceType)
+	return nil
+}
+
+// registeredRoles returns a snapshot of all registered roles.
+func registeredRoles() []roleInfo {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return slices.Clone(registry.roles)
+}
+
+// lookup returns the registered role with the given ID.
+func lookup(id Role) (roleInfo, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	role, ok := registry.byID[id]
+	return role, ok
+}
+
+// RegisterImplication declares that holding the role implies holding the
+// implied role, including all roles implied by the implied role, e.g. a
+// service-wide admin role implying the capabilities of a customer admin role.
+//
+// Both roles must be registered and belong to the same service. A role
+// associated with a resource type other than Service can only imply roles of
+// the same resource type, so that a role on a single resource never grants
+// service-wide access. It returns an error if the implication already exists
+// or would introduce a cycle.
+func RegisterImplication(role, implied Role) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	from, ok := registry.byID[role]
+	if !ok {
+		return errors.Newf("role %q is not registered", role)
+	}
+	to, ok := registry.byID[implied]
+	if !ok {
+		return errors.Newf("role %q is not registered", implied)
+	}
+	if from.service != to.service {
+		return errors.Newf("role %q cannot imply role %q of another service", role, implied)
+	}
+	if !from.resourceType.IsService() && from.resourceType != to.resourceType {
+		return errors.Newf("role %q of resource type %q cannot imply role %q of resource type %q",
+			role, from.resourceType, implied, to.resourceType)
+	}
+	if slices.Contains(registry.implies[role], implied) {
+		return errors.Newf("role %q already implies role %q", role, implied)
+	}
+	if role == implied || registry.impliesLocked(implied, role) {
+		return errors.Newf("implication from role %q to role %q would introduce a cycle", role, implied)
+	}
+	registry.implies[role] = append(registry.implies[role], implied)
+	return nil
+}
+
+// MustRegisterImplication is like RegisterImplication but panics if the
+// implication cannot be registered.
+func MustRegisterImplication(role, implied Role) {
+	if err := RegisterImplication(role, implied); err != nil {
+		panic(err)
+	}
+}
+
+// Implies returns true if holding role a grants role b, i.e. a and b are the
+// same role or a transitively implies b.
+func Implies(a, b Role) bool {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.impliesLocked(a, b)
+}
+
+// Expand returns the given roles along with all roles they transitively imply,
+// without duplicates. The given roles come first in their original order.
+func Expand(roles []Role) []Role {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	seen := make(map[Role]bool, len(roles))
+	expanded := make([]Role, 0, len(roles))
+	for _, role := range roles {
+		if !seen[role] {
+			seen[role] = true
+			expanded = append(expanded, role)
+		}
+	}
+	// expanded grows as we go, which makes this a breadth-first traversal.
+	for i := 0; i < len(expanded); i++ {
+		for _, implied := range registry.implies[expanded[i]] {
+			if !seen[implied] {
+				seen[implied] = true
+				expanded = append(expanded, implied)
+			}
+		}
+	}
+	return expanded
+}
+
+// impliesLocked is Implies for callers holding the registry lock.
+func (r *roleRegistry) impliesLocked(a, b Role) bool {
+	if a == b {
+		return true
+	}
+	for _, implied := range r.implies[a] {
+		if r.impliesLocked(implied, b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package roles
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+// resetRegistryAfterTest restores the registry to its current state once the
+// test is done, so that roles registered in the test do not leak.
+func resetRegistryAfterTest(t *testing.T) {
+	registry.mu.RLock()
+	roles := slices.Clone(registry.roles)
+	byID := maps.Clone(registry.byID)
+	resourceTypes := slices.Clone(registry.resourceTypes)
+	implies := make(map[Role][]Role, len(registry.implies))
+	for role, implied := range registry.implies {
+		implies[role] = slices.Clone(implied)
+	}
+	registry.mu.RUnlock()
+
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		registry.roles = roles
+		registry.byID = byID
+		registry.resourceTypes = resourceTypes
+		registry.implies = implies
+	})
+}
+
+func TestRegister(t *testing.T) {
+	resetRegistryAfterTest(t)
+
+	role, err := Register(services.Analytics, "viewer", Service)
+	require.NoError(t, err)
+	assert.Equal(t, Role("analytics::viewer"), role)
+	assert.True(t, Contains(role))
+	assert.Equal(t, Service, role.ResourceType())
+	assert.Contains(t, ByService()[services.Analytics], role)
+	assert.Contains(t, ServiceRolesByService()[services.Analytics], role)
+
+	for _, tc := range []struct {
+		name         string
+		service      services.Service
+		roleName     string
+		resourceType ResourceType
+		wantErr      string
+	}{
+		{
+			name:         "duplicate",
+			service:      services.Analytics,
+			roleName:     "viewer",
+			resourceType: Service,
+			wantErr:      `role "analytics::viewer" is already registered`,
+		},
+		{
+			name:         "duplicate built-in",
+			service:      services.Dotcom,
+			roleName:     "site_admin",
+			resourceType: Service,
+			wantErr:      `role "dotcom::site_admin" is already registered`,
+		},
+		{
+			name:         "unknown service",
+			service:      "foobar",
+			roleName:     "viewer",
+			resourceType: Service,
+			wantErr:      `unknown service "foobar"`,
+		},
+		{
+			name:         "invalid name",
+			service:      services.Analytics,
+			roleName:     "Viewer::all",
+			resourceType: Service,
+			wantErr:      `invalid role name "Viewer::all", must match ^[a-z][a-z0-9_]{0,63}$`,
+		},
+		{
+			name:         "unknown resource type",
+			service:      services.Analytics,
+			roleName:     "dashboard_viewer",
+			resourceType: "dashboard",
+			wantErr:      `unknown resource type "dashboard"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Register(tc.service, tc.roleName, tc.resourceType)
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+
+	t.Run("custom resource type", func(t *testing.T) {
+		require.NoError(t, RegisterResourceType("dashboard"))
+		assert.EqualError(t, RegisterResourceType("dashboard"), `resource type "dashboard" is already registered`)
+		assert.EqualError(t, RegisterResourceType("Dashboard"), `invalid resource type "Dashboard", must match ^[a-z][a-z_]{0,63}$`)
+		assert.EqualError(t, RegisterResourceType("unknown"), `resource type "unknown" is reserved`)
+		assert.Contains(t, ResourceTypes(), ResourceType("dashboard"))
+
+		role, err := Register(services.Analytics, "dashboard_viewer", "dashboard")
+		require.NoError(t, err)
+		assert.Equal(t, []Role{role}, ByResourceType()["dashboard"])
+	})
+}
+
+func TestMustRegister(t *testing.T) {
+	resetRegistryAfterTest(t)
+
+	assert.Panics(t, func() {
+		MustRegister(services.Dotcom, "site_admin", Service)
+	})
+}
+
+func TestRegisterConcurrently(t *testing.T) {
+	resetRegistryAfterTest(t)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := Register(services.Analytics, "role_"+string(rune('a'+i)), Service)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = List()
+			_ = Contains(RoleDotcomSiteAdmin)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, ByService()[services.Analytics], 10)
+}
+
+func TestImplications(t *testing.T) {
+	resetRegistryAfterTest(t)
+
+	owner := MustRegister(services.Analytics, "owner", Service)
+	editor := MustRegister(services.Analytics, "editor", Service)
+	viewer := MustRegister(services.Analytics, "viewer", Service)
+	require.NoError(t, RegisterResourceType("dashboard"))
+	dashboardEditor := MustRegister(services.Analytics, "dashboard_editor", "dashboard")
+	dashboardViewer := MustRegister(services.Analytics, "dashboard_viewer", "dashboard")
+
+	require.NoError(t, RegisterImplication(owner, editor))
+	require.NoError(t, RegisterImplication(editor, viewer))
+	require.NoError(t, RegisterImplication(editor, dashboardEditor))
+	require.NoError(t, RegisterImplication(dashboardEditor, dashboardViewer))
+
+	t.Run("Implies", func(t *testing.T) {
+		assert.True(t, Implies(owner, owner))
+		assert.True(t, Implies(owner, editor))
+		assert.True(t, Implies(owner, viewer))
+		assert.True(t, Implies(owner, dashboardViewer))
+		assert.False(t, Implies(viewer, editor))
+		assert.False(t, Implies(dashboardViewer, viewer))
+		assert.False(t, Implies(owner, RoleDotcomSiteAdmin))
+	})
+
+	t.Run("Expand", func(t *testing.T) {
+		assert.Equal(t,
+			[]Role{dashboardEditor, editor, dashboardViewer, viewer},
+			Expand([]Role{dashboardEditor, editor, dashboardEditor}))
+		assert.Equal(t, []Role{"unknown::role"}, Expand([]Role{"unknown::role"}))
+		assert.Empty(t, Expand(nil))
+	})
+
+	for _, tc := range []struct {
+		name    string
+		role    Role
+		implied Role
+		wantErr string
+	}{
+		{
+			name:    "cycle",
+			role:    viewer,
+			implied: owner,
+			wantErr: `implication from role "analytics::viewer" to role "analytics::owner" would introduce a cycle`,
+		},
+		{
+			name:    "self",
+			role:    viewer,
+			implied: viewer,
+			wantErr: `implication from role "analytics::viewer" to role "analytics::viewer" would introduce a cycle`,
+		},
+		{
+			name:    "duplicate",
+			role:    owner,
+			implied: editor,
+			wantErr: `role "analytics::owner" already implies role "analytics::editor"`,
+		},
+		{
+			name:    "unregistered",
+			role:    owner,
+			implied: "analytics::admin",
+			wantErr: `role "analytics::admin" is not registered`,
+		},
+		{
+			name:    "another service",
+			role:    owner,
+			implied: RoleDotcomSiteAdmin,
+			wantErr: `role "analytics::owner" cannot imply role "dotcom::site_admin" of another service`,
+		},
+		{
+			name:    "resource role implying service role",
+			role:    dashboardViewer,
+			implied: viewer,
+			wantErr: `role "analytics::dashboard_viewer" of resource type "dashboard" cannot imply role "analytics::viewer" of resource type "service"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, RegisterImplication(tc.role, tc.implied), tc.wantErr)
+		})
+	}
+}
+
+func TestBuiltinImplications(t *testing.T) {
+	assert.True(t, Implies(RoleEnterprisePortalServiceAdmin, RoleEnterprisePortalCustomerAdmin))
+	assert.False(t, Implies(RoleEnterprisePortalCustomerAdmin, RoleEnterprisePortalServiceAdmin))
+}
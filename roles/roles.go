@@ -18,21 +18,33 @@ func ToRole(service services.Service, name string) Role {
 	return Role(string(service) + "::" + name)
 }
 
-// Service returns the service that the role belongs to.
+// Service returns the service that the role belongs to. It returns an empty
+// string if the role is malformed.
 func (r Role) Service() services.Service {
-	return services.Service(r[:strings.Index(string(r), "::")])
+	service, _, ok := strings.Cut(string(r), "::")
+	if !ok {
+		return ""
+	}
+	return services.Service(service)
+}
+
+// Name returns the name of the role within its service, e.g. "site_admin". It
+// returns an empty string if the role is malformed.
+func (r Role) Name() string {
+	_, name, ok := strings.Cut(string(r), "::")
+	if !ok {
+		return ""
+	}
+	return name
 }
 
 // ResourceType returns the resource type that the role is associated with.
 // If the role is not registered, it returns "unknown".
 func (r Role) ResourceType() ResourceType {
-	for _, role := range registeredRoles {
-		if role.id == r {
-			return role.resourceType
-		}
+	if role, ok := lookup(r); ok {
+		return role.resourceType
 	}
-
-	return ResourceType("unknown")
+	return unknownResourceType
 }
 
 // ToStrings converts a list of roles to a list of strings.
@@ -45,7 +57,7 @@ func ToStrings(roles []Role) []string {
 }
 
 // ToRoles converts a list of strings to a list of roles.
-// It does not validate each input value.
+// It does not validate each input value, use ParseRoles for untrusted input.
 func ToRoles(strings []string) []Role {
 	roles := make([]Role, len(strings))
 	for i, s := range strings {
@@ -62,6 +74,10 @@ const (
 	Service ResourceType = "service"
 	// Subscription resources for Enterprise Portal.
 	EnterpriseSubscription ResourceType = "enterprise_subscription"
+
+	// unknownResourceType is returned for roles that are not registered, it is
+	// reserved and cannot be registered.
+	unknownResourceType ResourceType = "unknown"
 )
 
 // IsService returns true if the resource type is a service.
@@ -89,98 +105,46 @@ type roleInfo struct {
 // services.Dotcom
 var (
 	// Dotcom site admin
-	RoleDotcomSiteAdmin = ToRole(services.Dotcom, "site_admin")
-
-	dotcomRoles = []roleInfo{
-		{
-			id:           RoleDotcomSiteAdmin,
-			service:      services.Dotcom,
-			resourceType: Service,
-		},
-	}
+	RoleDotcomSiteAdmin = MustRegister(services.Dotcom, "site_admin", Service)
 )
 
 // services.SSC
 var (
 	// SSC admin
-	RoleSSCAdmin = ToRole(services.SSC, "admin")
-
-	sscRoles = []roleInfo{
-		{
-			id:           RoleSSCAdmin,
-			service:      services.SSC,
-			resourceType: Service,
-		},
-	}
+	RoleSSCAdmin = MustRegister(services.SSC, "admin", Service)
 )
 
 // services.EnterprisePortal
 var (
 	// Enterprise Portal customer admin
-	RoleEnterprisePortalCustomerAdmin = ToRole(services.EnterprisePortal, "customer_admin")
+	RoleEnterprisePortalCustomerAdmin = MustRegister(services.EnterprisePortal, "customer_admin", EnterpriseSubscription)
 
 	// Enterprise Portal internal Sourcegraph admin
-	RoleEnterprisePortalServiceAdmin = ToRole(services.EnterprisePortal, "service_admin")
-
-	enterprisePortalRoles = []roleInfo{
-		{
-			id:           RoleEnterprisePortalCustomerAdmin,
-			service:      services.EnterprisePortal,
-			resourceType: EnterpriseSubscription,
-		},
-		{
-			id:           RoleEnterprisePortalServiceAdmin,
-			service:      services.EnterprisePortal,
-			resourceType: Service,
-		},
-	}
+	RoleEnterprisePortalServiceAdmin = MustRegister(services.EnterprisePortal, "service_admin", Service)
 )
 
+func init() {
+	// Sourcegraph admins can do everything customer admins can do, on all
+	// subscriptions.
+	MustRegisterImplication(RoleEnterprisePortalServiceAdmin, RoleEnterprisePortalCustomerAdmin)
+}
+
 // services.Workspaces
 var (
-	RoleWorkspacesServiceAdmin = ToRole(services.Workspaces, "service_admin")
-	workspacesRoles            = []roleInfo{
-		{
-			id:           RoleWorkspacesServiceAdmin,
-			service:      services.Workspaces,
-			resourceType: Service,
-		},
-	}
+	RoleWorkspacesServiceAdmin = MustRegister(services.Workspaces, "service_admin", Service)
 )
 
 // services.ReleaseOperations
 var (
-	RoleReleaseOperationsAdmin = ToRole(services.ReleaseOperations, "release_admin")
-	releaseOperationsRoles     = []roleInfo{
-		{
-			id:           RoleReleaseOperationsAdmin,
-			service:      services.ReleaseOperations,
-			resourceType: Service,
-		},
-	}
+	RoleReleaseOperationsAdmin = MustRegister(services.ReleaseOperations, "release_admin", Service)
 )
 
-var registeredRoles = func() []roleInfo {
-	var registered []roleInfo
-
-	appendRoles := func(roles []roleInfo) {
-		registered = append(registered, roles...)
-	}
-
-	appendRoles(dotcomRoles)
-	appendRoles(sscRoles)
-	appendRoles(enterprisePortalRoles)
-	appendRoles(workspacesRoles)
-	appendRoles(releaseOperationsRoles)
-	// 👉 ADD YOUR ROLES HERE
-
-	return registered
-}()
+// 👉 ADD YOUR ROLES HERE, or register them from your service with Register.
 
 // List returns a list of all List
 func List() []Role {
 	var roles []Role
-	for _, role := range registeredRoles {
+	for _, role := range registeredRoles() {
 		roles = append(roles, role.id)
 	}
 	return roles
@@ -188,13 +152,14 @@ func List() []Role {
 
 // Contains returns true if the role is in the list of allowed roles
 func Contains(role Role) bool {
-	return slices.Contains(List(), role)
+	_, ok := lookup(role)
+	return ok
 }
 
 // ByService returns all allowed roles grouped by service.
 func ByService() map[services.Service][]Role {
 	byService := make(map[services.Service][]Role)
-	for _, role := range registeredRoles {
+	for _, role := range registeredRoles() {
 		byService[role.service] = append(byService[role.service], role.id)
 	}
 	return byService
@@ -203,7 +168,7 @@ func ByService() map[services.Service][]Role {
 // ByResourceType returns all allowed roles grouped by resource type.
 func ByResourceType() map[ResourceType][]Role {
 	byResourceType := make(map[ResourceType][]Role)
-	for _, role := range registeredRoles {
+	for _, role := range registeredRoles() {
 		byResourceType[role.resourceType] = append(byResourceType[role.resourceType], role.id)
 	}
 	return byResourceType
@@ -212,7 +177,7 @@ func ByResourceType() map[ResourceType][]Role {
 // ServiceRolesByService returns all allowed service roles grouped by service.
 func ServiceRolesByService() map[services.Service][]Role {
 	byService := make(map[services.Service][]Role)
-	for _, role := range registeredRoles {
+	for _, role := range registeredRoles() {
 		if role.resourceType.IsService() {
 			byService[role.service] = append(byService[role.service], role.id)
 		}
@@ -222,9 +187,7 @@ func ServiceRolesByService() map[services.Service][]Role {
 
 // ResourceTypes returns all allowed resource types.
 func ResourceTypes() []ResourceType {
-	var resourceTypes []ResourceType
-	for _, role := range registeredRoles {
-		resourceTypes = append(resourceTypes, role.resourceType)
-	}
-	return resourceTypes
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return slices.Clone(registry.resourceTypes)
 }
@@ -0,0 +1,78 @@
+package sams
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/log"
+)
+
+// routineRunner implements the lifecycle shared by the background routines of
+// this package: run blocks on the loop of the routine until stop is called.
+// Once stopped, a routine cannot be restarted.
+type routineRunner struct {
+	logger log.Logger
+
+	// mu protects the fields below.
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	cancel  context.CancelFunc
+	// done is closed when run returns.
+	done chan struct{}
+}
+
+func newRoutineRunner(logger log.Logger) *routineRunner {
+	return &routineRunner{
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+}
+
+// run calls loop with a context that is canceled by stop, and blocks until
+// loop returns. It returns immediately without calling loop if stop has already
+// been called. It panics with the given name of the routine if called more
+// than once.
+func (r *routineRunner) run(name string, loop func(ctx context.Context)) {
+	r.mu.Lock()
+	if r.started {
+		r.mu.Unlock()
+		panic(name + " already started")
+	}
+	r.started = true
+	if r.stopped {
+		r.mu.Unlock()
+		close(r.done)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.mu.Unlock()
+
+	defer close(r.done)
+	loop(ctx)
+}
+
+// stop cancels the context of run and waits for the loop to return, or until
+// ctx is done. If run has not been called yet, it returns immediately and any
+// later call to run returns without calling loop.
+func (r *routineRunner) stop(ctx context.Context) error {
+	r.mu.Lock()
+	r.stopped = true
+	started := r.started
+	if started {
+		r.cancel()
+	}
+	r.mu.Unlock()
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-r.done:
+		r.logger.Info("stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
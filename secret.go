@@ -0,0 +1,75 @@
+package sams
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+)
+
+const redactedSecret = "[REDACTED]"
+
+// Secret is a sensitive value, e.g. a client secret or the secret of a service
+// access token. It redacts itself when printed with any fmt verb, marshaled to
+// JSON, or logged, so that it does not leak when a surrounding struct is. Use
+// Reveal to access the value.
+//
+// An empty Secret is not redacted, to tell apart unset secrets.
+type Secret string
+
+var (
+	_ fmt.Stringer   = Secret("")
+	_ fmt.GoStringer = Secret("")
+	_ fmt.Formatter  = Secret("")
+	_ json.Marshaler = Secret("")
+	_ slog.LogValuer = Secret("")
+)
+
+// Reveal returns the secret value. 🚨 SECURITY: The value MUST NOT be logged.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String returns a redacted representation of the secret.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redactedSecret
+}
+
+// GoString returns a redacted representation of the secret for the %#v verb.
+func (s Secret) GoString() string {
+	return "sams.Secret(" + strconv.Quote(s.String()) + ")"
+}
+
+// Format implements fmt.Formatter so that all verbs, including %x and %q,
+// print the redacted representation of the secret.
+func (s Secret) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		_, _ = fmt.Fprint(f, s.GoString())
+	case verb == 'q':
+		_, _ = fmt.Fprint(f, strconv.Quote(s.String()))
+	default:
+		_, _ = fmt.Fprint(f, s.String())
+	}
+}
+
+// MarshalJSON marshals the redacted representation of the secret. Note that
+// unmarshaling a Secret from JSON yields the original value.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// LogValue implements slog.LogValuer.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// LogField returns a log field with the redacted representation of the secret.
+func (s Secret) LogField(key string) log.Field {
+	return log.String(key, s.String())
+}
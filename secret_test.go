@@ -0,0 +1,34 @@
+package sams
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	secret := Secret("sams_sat_supersecret")
+	assert.Equal(t, "sams_sat_supersecret", secret.Reveal())
+
+	resp := CreateServiceAccessTokenResponse{Secret: secret}
+	for _, format := range []string{"%s", "%v", "%+v", "%#v", "%q", "%x", "%d"} {
+		t.Run(format, func(t *testing.T) {
+			assert.NotContains(t, fmt.Sprintf(format, secret), "supersecret")
+			assert.NotContains(t, fmt.Sprintf(format, resp), "supersecret")
+			assert.NotContains(t, fmt.Sprintf(format, &resp), "supersecret")
+		})
+	}
+	autogold.Expect(`{Token:<nil> Secret:[REDACTED]}`).Equal(t, fmt.Sprintf("%+v", resp))
+	autogold.Expect(`sams.Secret("[REDACTED]")`).Equal(t, fmt.Sprintf("%#v", secret))
+
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+	autogold.Expect(`{"Token":null,"Secret":"[REDACTED]"}`).Equal(t, string(data))
+
+	assert.Equal(t, "[REDACTED]", secret.LogValue().String())
+	assert.Equal(t, "", Secret("").String(), "empty secrets are not redacted")
+}
@@ -1,5 +1,10 @@
 package services
 
+import (
+	"maps"
+	"slices"
+)
+
 // Service is a type for the service part of a scope and/or role.
 type Service string
 
@@ -40,3 +45,8 @@ func (s Service) DisplayName() string {
 	}
 	return string(s)
 }
+
+// List returns all registered services, sorted by name.
+func List() []Service {
+	return slices.Sorted(maps.Keys(serviceNames))
+}
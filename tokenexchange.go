@@ -0,0 +1,194 @@
+package sams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+// See https://datatracker.ietf.org/doc/html/rfc8693#section-2.1
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// Exchanged tokens are short-lived and the subject token may expire at any
+// time, so we want to refresh them a bit earlier than the default of
+// oauth2.ReuseTokenSource.
+var tokenExchangeExpiryDelta = 30 * time.Second
+
+// TokenExchangeConfig is the configuration for TokenExchangeTokenSource.
+type TokenExchangeConfig struct {
+	ConnConfig
+	// ClientID is the SAMS client ID of the service performing the exchange, e.g.
+	// "sams_cid_xxx".
+	ClientID string
+	// ClientSecret is the SAMS client secret of the service performing the
+	// exchange, e.g. "sams_cs_xxx".
+	ClientSecret Secret
+	// SubjectTokenSource provides the SAMS user access token to exchange, i.e.
+	// the token the calling user presented to this service. Use
+	// oauth2.StaticTokenSource if only the raw access token is available.
+	SubjectTokenSource oauth2.TokenSource
+	// Audience is the SAMS-protected service the exchanged token is intended
+	// for.
+	Audience services.Service
+	// RequestScopes is the list of scopes requested for the exchanged token. It
+	// MUST be a subset of the scopes of the subject token.
+	RequestScopes []scopes.Scope
+	// HTTPClient is the HTTP client to use for calling the SAMS token endpoint.
+	//
+	// The default is http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func (c TokenExchangeConfig) Validate() error {
+	if err := c.ConnConfig.Validate(); err != nil {
+		return errors.Wrap(err, "invalid ConnConfig")
+	}
+	if c.ClientID == "" {
+		return errors.New("client ID is required")
+	}
+	if c.SubjectTokenSource == nil {
+		return errors.New("subject token source is required")
+	}
+	if c.Audience == "" {
+		return errors.New("audience is required")
+	}
+	if len(c.RequestScopes) == 0 {
+		return errors.New("at least one request scope is required")
+	}
+	return nil
+}
+
+// TokenExchangeTokenSource returns a TokenSource that exchanges the subject
+// token (a SAMS user access token) for a down-scoped access token intended for
+// the audience service using OAuth 2.0 Token Exchange (RFC 8693), so that the
+// service can call the audience service on behalf of the user.
+//
+// The exchanged token is reused until it is close to expiry, so callers
+// should hold onto the returned TokenSource for as long as they act on behalf
+// of the same user. The given context is used for all token exchange requests,
+// and cancelling it aborts any in-flight exchange.
+func TokenExchangeTokenSource(ctx context.Context, config TokenExchangeConfig) (oauth2.TokenSource, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Wrap(err, "TokenExchangeConfig is invalid")
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return oauth2.ReuseTokenSourceWithExpiry(
+		nil,
+		&tokenExchangeTokenSource{
+			ctx:        ctx,
+			config:     config,
+			tokenURL:   fmt.Sprintf("%s/oauth/token", config.getAPIURL()),
+			httpClient: httpClient,
+		},
+		tokenExchangeExpiryDelta,
+	), nil
+}
+
+type tokenExchangeTokenSource struct {
+	ctx        context.Context
+	config     TokenExchangeConfig
+	tokenURL   string
+	httpClient *http.Client
+}
+
+// tokenExchangeResponse is the successful response of a token exchange, see
+// https://datatracker.ietf.org/doc/html/rfc8693#section-2.2.1
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	Scope           string `json:"scope"`
+}
+
+func (s *tokenExchangeTokenSource) Token() (*oauth2.Token, error) {
+	subject, err := s.config.SubjectTokenSource.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "get subject token")
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", grantTypeTokenExchange)
+	params.Set("subject_token", subject.AccessToken)
+	params.Set("subject_token_type", tokenTypeAccessToken)
+	params.Set("requested_token_type", tokenTypeAccessToken)
+	params.Set("audience", string(s.config.Audience))
+	params.Set("scope", strings.Join(scopes.ToStrings(s.config.RequestScopes), " "))
+
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.tokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Client credentials MUST be URL-encoded before being used in the basic
+	// authentication, see https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret.Reveal()))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "exchange token")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.Wrap(err, "read response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		retrieveErr := &oauth2.RetrieveError{Response: resp, Body: body}
+		if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/json" {
+			var errResp struct {
+				Error            string `json:"error"`
+				ErrorDescription string `json:"error_description"`
+				ErrorURI         string `json:"error_uri"`
+			}
+			if json.Unmarshal(body, &errResp) == nil {
+				retrieveErr.ErrorCode = errResp.Error
+				retrieveErr.ErrorDescription = errResp.ErrorDescription
+				retrieveErr.ErrorURI = errResp.ErrorURI
+			}
+		}
+		return nil, retrieveErr
+	}
+
+	var tokenResp tokenExchangeResponse
+	if err = json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal response")
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, errors.New(`missing "access_token" in response`)
+	}
+	if tokenResp.IssuedTokenType != "" && tokenResp.IssuedTokenType != tokenTypeAccessToken {
+		return nil, errors.Newf("unexpected issued token type %q", tokenResp.IssuedTokenType)
+	}
+
+	token := &oauth2.Token{
+		AccessToken: tokenResp.AccessToken,
+		TokenType:   tokenResp.TokenType,
+	}
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+	return token.WithExtra(map[string]any{
+		"issued_token_type": tokenResp.IssuedTokenType,
+		"scope":             tokenResp.Scope,
+	}), nil
+}
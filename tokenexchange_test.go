@@ -0,0 +1,134 @@
+package sams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/scopes"
+	"github.com/sourcegraph/sourcegraph-accounts-sdk-go/services"
+)
+
+// newFakeTokenExchangeServer returns a fake SAMS token endpoint that issues
+// exchanged tokens expiring in the given duration, and a pointer to the number
+// of exchanges performed.
+func newFakeTokenExchangeServer(t *testing.T, expiresIn time.Duration) (*httptest.Server, *int) {
+	var exchanges int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "fooclient" || clientSecret != "barsecret" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"error":             "invalid_client",
+				"error_description": "client authentication failed",
+			})
+			return
+		}
+
+		assert.Equal(t, grantTypeTokenExchange, r.PostForm.Get("grant_type"))
+		assert.Equal(t, tokenTypeAccessToken, r.PostForm.Get("subject_token_type"))
+		assert.Equal(t, tokenTypeAccessToken, r.PostForm.Get("requested_token_type"))
+		assert.Equal(t, "sams_at_user", r.PostForm.Get("subject_token"))
+		assert.Equal(t, "telemetry_gateway", r.PostForm.Get("audience"))
+		assert.Equal(t, "telemetry_gateway::events::write", r.PostForm.Get("scope"))
+
+		exchanges++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      "sams_at_exchanged",
+			"issued_token_type": tokenTypeAccessToken,
+			"token_type":        "Bearer",
+			"expires_in":        int64(expiresIn.Seconds()),
+			"scope":             r.PostForm.Get("scope"),
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &exchanges
+}
+
+func TestTokenExchangeTokenSource(t *testing.T) {
+	newConfig := func(srv *httptest.Server) TokenExchangeConfig {
+		return TokenExchangeConfig{
+			ConnConfig:         ConnConfig{ExternalURL: srv.URL},
+			ClientID:           "fooclient",
+			ClientSecret:       "barsecret",
+			SubjectTokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "sams_at_user"}),
+			Audience:           services.TelemetryGateway,
+			RequestScopes: []scopes.Scope{
+				scopes.ToScope(services.TelemetryGateway, "events", scopes.ActionWrite),
+			},
+		}
+	}
+
+	t.Run("reuses token until close to expiry", func(t *testing.T) {
+		srv, exchanges := newFakeTokenExchangeServer(t, time.Hour)
+		ts, err := TokenExchangeTokenSource(context.Background(), newConfig(srv))
+		require.NoError(t, err)
+
+		for range 3 {
+			token, err := ts.Token()
+			require.NoError(t, err)
+			assert.Equal(t, "sams_at_exchanged", token.AccessToken)
+			assert.Equal(t, "telemetry_gateway::events::write", token.Extra("scope"))
+		}
+		assert.Equal(t, 1, *exchanges)
+	})
+
+	t.Run("exchanges again when close to expiry", func(t *testing.T) {
+		srv, exchanges := newFakeTokenExchangeServer(t, tokenExchangeExpiryDelta/2)
+		ts, err := TokenExchangeTokenSource(context.Background(), newConfig(srv))
+		require.NoError(t, err)
+
+		for range 3 {
+			_, err := ts.Token()
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, *exchanges)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		srv, _ := newFakeTokenExchangeServer(t, time.Hour)
+		config := newConfig(srv)
+		config.ClientSecret = "wrongsecret"
+		ts, err := TokenExchangeTokenSource(context.Background(), config)
+		require.NoError(t, err)
+
+		_, err = ts.Token()
+		var retrieveErr *oauth2.RetrieveError
+		require.ErrorAs(t, err, &retrieveErr)
+		assert.Equal(t, "invalid_client", retrieveErr.ErrorCode)
+		autogold.Expect("client authentication failed").Equal(t, retrieveErr.ErrorDescription)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		srv, exchanges := newFakeTokenExchangeServer(t, time.Hour)
+		ctx, cancel := context.WithCancel(context.Background())
+		ts, err := TokenExchangeTokenSource(ctx, newConfig(srv))
+		require.NoError(t, err)
+
+		cancel()
+		_, err = ts.Token()
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, *exchanges)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := TokenExchangeTokenSource(context.Background(), TokenExchangeConfig{
+			ConnConfig: ConnConfig{ExternalURL: "https://accounts.sourcegraph.com"},
+			ClientID:   "fooclient",
+		})
+		autogold.Expect("TokenExchangeConfig is invalid: subject token source is required").Equal(t, err.Error())
+	})
+}